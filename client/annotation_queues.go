@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"net/url"
+)
+
+// ListAnnotationQueueItems calls GET /api/public/annotation-queues/{queueId}/items,
+// following pages until all items have been fetched. An empty status lists
+// items of every status. Requires public API credentials of the project
+// owning the queue. The returned error matches ErrNotFound when the queue does
+// not exist.
+func (c *Client) ListAnnotationQueueItems(ctx context.Context, queueID string, status AnnotationQueueStatus) ([]AnnotationQueueItem, error) {
+	apiPath, err := escapePath("/api/public/annotation-queues/%s/items", queueID)
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		apiPath += "?" + url.Values{"status": {string(status)}}.Encode()
+	}
+	return ListAll(ctx, c.pageSize, func(ctx context.Context, page, limit int) (Page[AnnotationQueueItem], error) {
+		return fetchPage[AnnotationQueueItem](ctx, c, publicAPI, "list annotation queue items", apiPath, "data", page, limit)
+	})
+}
@@ -0,0 +1,30 @@
+package client
+
+import "context"
+
+// LangfuseAPI is the set of API operations used by the provider's resources.
+// *Client implements it against a live instance; clientfake provides an
+// in-memory implementation for tests.
+type LangfuseAPI interface {
+	ListOrganizations(ctx context.Context) ([]Organization, error)
+	CreateOrganization(ctx context.Context, name string) (*Organization, error)
+	GetOrganization(ctx context.Context, orgID string) (*Organization, error)
+	UpdateOrganization(ctx context.Context, orgID string, update OrganizationUpdate) (*Organization, error)
+	DeleteOrganization(ctx context.Context, orgID string) error
+
+	ListProjects(ctx context.Context, orgID string) ([]Project, error)
+	CreateProject(ctx context.Context, orgID string, create ProjectCreate) (*Project, error)
+	GetProject(ctx context.Context, orgID, projID string) (*Project, error)
+	UpdateProject(ctx context.Context, orgID, projID string, update ProjectUpdate) (*Project, error)
+	DeleteProject(ctx context.Context, orgID, projID string) error
+	ListProjectAPIKeys(ctx context.Context, orgID, projID string) ([]APIKey, error)
+	CreateProjectAPIKey(ctx context.Context, orgID, projID string, create APIKeyCreate) (*APIKey, error)
+	DeleteProjectAPIKey(ctx context.Context, orgID, projID, keyID string) error
+
+	GetPrompt(ctx context.Context, name string, sel PromptSelector) (*Prompt, error)
+	SetPromptLabels(ctx context.Context, name string, version int, labels []string) (*Prompt, error)
+	GetDatasetRun(ctx context.Context, datasetName, runName string) (*DatasetRun, error)
+	ListAnnotationQueueItems(ctx context.Context, queueID string, status AnnotationQueueStatus) ([]AnnotationQueueItem, error)
+}
+
+var _ LangfuseAPI = (*Client)(nil)
@@ -0,0 +1,357 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedAPI wraps a LangfuseAPI and caches successful reads of organizations
+// and projects, as well as organizations found missing. It is meant to live
+// for a single Terraform operation, where many resources read the same
+// objects, e.g. every project validating and reading its organization.
+//
+// Organization lists also fill the cache of individual organizations.
+// Concurrent identical reads that miss the cache share one in-flight request.
+// Writes made through the wrapper invalidate the entries they affect; changes
+// made outside of it are not observed.
+//
+// Project reads are batched: the first read of a project fetches the project
+// list of its organization once and serves the reads of all its siblings from
+// it, so refreshing many projects costs a few list pages instead of one
+// request per project. Projects missing from the list, and organizations
+// whose list cannot be fetched, fall back to individual reads.
+type CachedAPI struct {
+	LangfuseAPI
+
+	inflight singleflight.Group
+
+	mu       sync.Mutex
+	orgs     map[string]Organization
+	orgList  []Organization
+	listOK   bool
+	projects map[projectKey]Project
+	// missingOrgs holds the not-found errors of organization reads, so that
+	// all projects referencing a wrong organization ID share one request.
+	missingOrgs map[string]error
+	// listed holds the organizations whose project list has been fetched
+	// into projects; a false entry means listing failed and is not retried.
+	listed map[string]bool
+}
+
+// sharedReadTimeout bounds a read shared by concurrent callers. It runs
+// detached from the context of the caller that started it, so that caller's
+// deadline no longer applies.
+const sharedReadTimeout = 5 * time.Minute
+
+// share runs fn once for all concurrent callers passing the same key and
+// returns its result. fn does not inherit the cancellation of the caller that
+// happened to start it, so that caller giving up does not fail the others;
+// each caller still returns as soon as its own ctx is done.
+func (c *CachedAPI) share(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	ch := c.inflight.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedReadTimeout)
+		defer cancel()
+		return fn(ctx)
+	})
+	select {
+	case res := <-ch:
+		return res.Val, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// bypassCacheKey marks a context whose reads must not be served from the cache.
+type bypassCacheKey struct{}
+
+// WithoutCache returns a context for which CachedAPI reads go to the wrapped
+// API, e.g. when polling for a change made by the server in the background.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// bypassCache reports whether ctx was created by WithoutCache.
+func bypassCache(ctx context.Context) bool {
+	v, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return v
+}
+
+// projectKey identifies a cached project.
+type projectKey struct {
+	orgID, projID string
+}
+
+var _ LangfuseAPI = (*CachedAPI)(nil)
+
+// NewCachedAPI returns a caching wrapper around api.
+func NewCachedAPI(api LangfuseAPI) *CachedAPI {
+	return &CachedAPI{
+		LangfuseAPI: api,
+		orgs:        map[string]Organization{},
+		missingOrgs: map[string]error{},
+		projects:    map[projectKey]Project{},
+		listed:      map[string]bool{},
+	}
+}
+
+// ListOrganizations implements LangfuseAPI.
+func (c *CachedAPI) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	if bypassCache(ctx) {
+		return c.LangfuseAPI.ListOrganizations(ctx)
+	}
+	c.mu.Lock()
+	if c.listOK {
+		orgs := append([]Organization(nil), c.orgList...)
+		c.mu.Unlock()
+		return orgs, nil
+	}
+	c.mu.Unlock()
+
+	v, err := c.share(ctx, "orgs", func(ctx context.Context) (interface{}, error) {
+		orgs, err := c.LangfuseAPI.ListOrganizations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.orgList = append([]Organization(nil), orgs...)
+		c.listOK = true
+		for _, org := range orgs {
+			c.orgs[org.ID] = org
+		}
+		c.mu.Unlock()
+		return orgs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Organization(nil), v.([]Organization)...), nil
+}
+
+// CreateOrganization implements LangfuseAPI.
+func (c *CachedAPI) CreateOrganization(ctx context.Context, name string) (*Organization, error) {
+	defer c.invalidateOrg("")
+	return c.LangfuseAPI.CreateOrganization(ctx, name)
+}
+
+// GetOrganization implements LangfuseAPI.
+func (c *CachedAPI) GetOrganization(ctx context.Context, orgID string) (*Organization, error) {
+	if bypassCache(ctx) {
+		return c.LangfuseAPI.GetOrganization(ctx, orgID)
+	}
+	c.mu.Lock()
+	if org, ok := c.orgs[orgID]; ok {
+		c.mu.Unlock()
+		return &org, nil
+	}
+	if err, ok := c.missingOrgs[orgID]; ok {
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.mu.Unlock()
+
+	v, err := c.share(ctx, "org/"+orgID, func(ctx context.Context) (interface{}, error) {
+		org, err := c.LangfuseAPI.GetOrganization(ctx, orgID)
+		if errors.Is(err, ErrNotFound) {
+			c.mu.Lock()
+			c.missingOrgs[orgID] = err
+			c.mu.Unlock()
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.orgs[orgID] = *org
+		c.mu.Unlock()
+		return *org, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	org := v.(Organization)
+	return &org, nil
+}
+
+// UpdateOrganization implements LangfuseAPI.
+func (c *CachedAPI) UpdateOrganization(ctx context.Context, orgID string, update OrganizationUpdate) (*Organization, error) {
+	defer c.invalidateOrg(orgID)
+	return c.LangfuseAPI.UpdateOrganization(ctx, orgID, update)
+}
+
+// DeleteOrganization implements LangfuseAPI.
+func (c *CachedAPI) DeleteOrganization(ctx context.Context, orgID string) error {
+	defer c.invalidateOrg(orgID)
+	return c.LangfuseAPI.DeleteOrganization(ctx, orgID)
+}
+
+// ListProjects implements LangfuseAPI.
+func (c *CachedAPI) ListProjects(ctx context.Context, orgID string) ([]Project, error) {
+	if bypassCache(ctx) {
+		return c.LangfuseAPI.ListProjects(ctx, orgID)
+	}
+	v, err := c.share(ctx, "projects/"+orgID, func(ctx context.Context) (interface{}, error) {
+		c.mu.Lock()
+		if c.listed[orgID] {
+			projects := c.orgProjects(orgID)
+			c.mu.Unlock()
+			return projects, nil
+		}
+		c.mu.Unlock()
+		projects, err := c.LangfuseAPI.ListProjects(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		for _, proj := range projects {
+			c.projects[projectKey{orgID, proj.ID}] = proj
+		}
+		c.listed[orgID] = true
+		c.mu.Unlock()
+		return projects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]Project(nil), v.([]Project)...), nil
+}
+
+// loadProjects fetches the project list of orgID into the cache unless that
+// has already been done, and reports whether the cached projects of orgID are
+// complete. A failed list is remembered, so later reads go straight to
+// GetProject.
+func (c *CachedAPI) loadProjects(ctx context.Context, orgID string) bool {
+	c.mu.Lock()
+	done, ok := c.listed[orgID]
+	c.mu.Unlock()
+	if ok {
+		return done
+	}
+	if _, err := c.ListProjects(ctx, orgID); err != nil {
+		c.mu.Lock()
+		if _, ok := c.listed[orgID]; !ok {
+			c.listed[orgID] = false
+		}
+		c.mu.Unlock()
+		return false
+	}
+	return true
+}
+
+// orgProjects returns the cached projects of orgID sorted by ID. Callers hold
+// c.mu.
+func (c *CachedAPI) orgProjects(orgID string) []Project {
+	var projects []Project
+	for key, proj := range c.projects {
+		if key.orgID == orgID {
+			projects = append(projects, proj)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects
+}
+
+// CreateProject implements LangfuseAPI.
+func (c *CachedAPI) CreateProject(ctx context.Context, orgID string, create ProjectCreate) (*Project, error) {
+	defer c.invalidateProjectList(orgID)
+	return c.LangfuseAPI.CreateProject(ctx, orgID, create)
+}
+
+// GetProject implements LangfuseAPI.
+func (c *CachedAPI) GetProject(ctx context.Context, orgID, projID string) (*Project, error) {
+	if bypassCache(ctx) {
+		return c.LangfuseAPI.GetProject(ctx, orgID, projID)
+	}
+	key := projectKey{orgID, projID}
+	c.mu.Lock()
+	if proj, ok := c.projects[key]; ok {
+		c.mu.Unlock()
+		return &proj, nil
+	}
+	c.mu.Unlock()
+
+	if c.loadProjects(ctx, orgID) {
+		c.mu.Lock()
+		proj, ok := c.projects[key]
+		c.mu.Unlock()
+		if ok {
+			return &proj, nil
+		}
+	}
+
+	v, err := c.share(ctx, "project/"+orgID+"/"+projID, func(ctx context.Context) (interface{}, error) {
+		proj, err := c.LangfuseAPI.GetProject(ctx, orgID, projID)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.projects[key] = *proj
+		c.mu.Unlock()
+		return *proj, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	proj := v.(Project)
+	return &proj, nil
+}
+
+// UpdateProject implements LangfuseAPI.
+func (c *CachedAPI) UpdateProject(ctx context.Context, orgID, projID string, update ProjectUpdate) (*Project, error) {
+	defer c.invalidateProject(orgID, projID)
+	return c.LangfuseAPI.UpdateProject(ctx, orgID, projID, update)
+}
+
+// DeleteProject implements LangfuseAPI.
+func (c *CachedAPI) DeleteProject(ctx context.Context, orgID, projID string) error {
+	defer c.invalidateProject(orgID, projID)
+	return c.LangfuseAPI.DeleteProject(ctx, orgID, projID)
+}
+
+// invalidateOrg drops the cached organization list and, if orgID is set, the
+// organization and its projects.
+func (c *CachedAPI) invalidateOrg(orgID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.orgList = nil
+	c.listOK = false
+	clear(c.missingOrgs)
+	if orgID == "" {
+		return
+	}
+	delete(c.orgs, orgID)
+	delete(c.listed, orgID)
+	for key := range c.projects {
+		if key.orgID == orgID {
+			delete(c.projects, key)
+		}
+	}
+}
+
+// invalidateProject drops a cached project and the project list of its
+// organization.
+func (c *CachedAPI) invalidateProject(orgID, projID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.projects, projectKey{orgID, projID})
+	delete(c.listed, orgID)
+}
+
+// invalidateProjectList drops the cached project list of orgID. The projects
+// read so far stay cached.
+func (c *CachedAPI) invalidateProjectList(orgID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.listed, orgID)
+}
+
+// LogMetrics writes the request statistics of the wrapped client to the
+// Terraform log, if it collects any.
+func (c *CachedAPI) LogMetrics(ctx context.Context) {
+	if m, ok := c.LangfuseAPI.(interface{ LogMetrics(context.Context) }); ok {
+		m.LogMetrics(ctx)
+	}
+}
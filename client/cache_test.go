@@ -0,0 +1,244 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+)
+
+// countingAPI counts the project reads reaching the wrapped API.
+type countingAPI struct {
+	client.LangfuseAPI
+	lists, gets atomic.Int32
+	listErr     error
+}
+
+func (c *countingAPI) ListProjects(ctx context.Context, orgID string) ([]client.Project, error) {
+	c.lists.Add(1)
+	if c.listErr != nil {
+		return nil, c.listErr
+	}
+	return c.LangfuseAPI.ListProjects(ctx, orgID)
+}
+
+func (c *countingAPI) GetProject(ctx context.Context, orgID, projID string) (*client.Project, error) {
+	c.gets.Add(1)
+	return c.LangfuseAPI.GetProject(ctx, orgID, projID)
+}
+
+// newProjects creates an organization with n projects and returns their IDs.
+func newProjects(t *testing.T, api client.LangfuseAPI, n int) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+	org, err := api.CreateOrganization(ctx, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for i := range n {
+		proj, err := api.CreateProject(ctx, org.ID, client.ProjectCreate{Name: fmt.Sprintf("project-%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, proj.ID)
+	}
+	return org.ID, ids
+}
+
+func TestCachedAPIBatchesProjectReads(t *testing.T) {
+	ctx := context.Background()
+	api := &countingAPI{LangfuseAPI: clientfake.New()}
+	orgID, ids := newProjects(t, api, 50)
+	cached := client.NewCachedAPI(api)
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			proj, err := cached.GetProject(ctx, orgID, id)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if proj.ID != id || proj.SecretKey != "" {
+				t.Errorf("GetProject(%s) = %+v", id, proj)
+			}
+		}()
+	}
+	wg.Wait()
+	if lists, gets := api.lists.Load(), api.gets.Load(); lists != 1 || gets != 0 {
+		t.Errorf("reading %d projects sent %d list and %d get requests, want 1 and 0", len(ids), lists, gets)
+	}
+
+	// A project created through the wrapper is listed on the next read.
+	proj, err := cached.CreateProject(ctx, orgID, client.ProjectCreate{Name: "new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cached.GetProject(ctx, orgID, proj.ID); err != nil {
+		t.Fatal(err)
+	}
+	projects, err := cached.ListProjects(ctx, orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != len(ids)+1 {
+		t.Errorf("ListProjects returned %d projects, want %d", len(projects), len(ids)+1)
+	}
+	if lists, gets := api.lists.Load(), api.gets.Load(); lists != 2 || gets != 0 {
+		t.Errorf("got %d list and %d get requests after create, want 2 and 0", lists, gets)
+	}
+
+	// Unknown projects are confirmed with an individual read.
+	if _, err := cached.GetProject(ctx, orgID, "missing"); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("GetProject of a missing project returned %v, want ErrNotFound", err)
+	}
+	if gets := api.gets.Load(); gets != 1 {
+		t.Errorf("got %d get requests, want 1", gets)
+	}
+}
+
+func TestCachedAPIFallsBackWhenListingFails(t *testing.T) {
+	ctx := context.Background()
+	api := &countingAPI{LangfuseAPI: clientfake.New()}
+	orgID, ids := newProjects(t, api, 3)
+	api.listErr = errors.New("listing not supported")
+	cached := client.NewCachedAPI(api)
+
+	for _, id := range ids {
+		if _, err := cached.GetProject(ctx, orgID, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if lists, gets := api.lists.Load(), api.gets.Load(); lists != 1 || gets != int32(len(ids)) {
+		t.Errorf("got %d list and %d get requests, want 1 and %d", lists, gets, len(ids))
+	}
+}
+
+// countingOrgs counts the organization reads reaching the wrapped API.
+type countingOrgs struct {
+	client.LangfuseAPI
+	gets atomic.Int32
+}
+
+func (c *countingOrgs) GetOrganization(ctx context.Context, orgID string) (*client.Organization, error) {
+	c.gets.Add(1)
+	return c.LangfuseAPI.GetOrganization(ctx, orgID)
+}
+
+func TestCachedAPIReusesOrganizationLookups(t *testing.T) {
+	ctx := context.Background()
+	api := &countingOrgs{LangfuseAPI: clientfake.New()}
+	orgID, _ := newProjects(t, api, 0)
+	cached := client.NewCachedAPI(api)
+
+	for range 10 {
+		if _, err := cached.GetOrganization(ctx, orgID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cached.GetOrganization(ctx, "missing"); !errors.Is(err, client.ErrNotFound) {
+			t.Fatalf("GetOrganization of a missing organization returned %v, want ErrNotFound", err)
+		}
+	}
+	if gets := api.gets.Load(); gets != 2 {
+		t.Errorf("got %d get requests, want 2", gets)
+	}
+
+	// Listed organizations need no further reads.
+	other, err := cached.CreateOrganization(ctx, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cached.ListOrganizations(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cached.GetOrganization(ctx, other.ID); err != nil {
+		t.Fatal(err)
+	}
+	if gets := api.gets.Load(); gets != 2 {
+		t.Errorf("got %d get requests after listing, want 2", gets)
+	}
+}
+
+// racingCreateAPI lists organizations through the cache while an
+// organization is being created, like a concurrent resource refresh would.
+type racingCreateAPI struct {
+	client.LangfuseAPI
+	cached *client.CachedAPI
+}
+
+func (r *racingCreateAPI) CreateOrganization(ctx context.Context, name string) (*client.Organization, error) {
+	r.cached.ListOrganizations(ctx)
+	return r.LangfuseAPI.CreateOrganization(ctx, name)
+}
+
+func TestCachedAPIInvalidatesAfterCreate(t *testing.T) {
+	ctx := context.Background()
+	api := &racingCreateAPI{LangfuseAPI: clientfake.New()}
+	api.cached = client.NewCachedAPI(api)
+
+	org, err := api.cached.CreateOrganization(ctx, "team")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orgs, err := api.cached.ListOrganizations(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 1 || orgs[0].ID != org.ID {
+		t.Errorf("organizations after create = %v, want the new organization", orgs)
+	}
+}
+
+// blockingOrgs holds organization reads until released and reports the
+// context error the read observes.
+type blockingOrgs struct {
+	client.LangfuseAPI
+	started, release chan struct{}
+}
+
+func (b *blockingOrgs) GetOrganization(ctx context.Context, orgID string) (*client.Organization, error) {
+	close(b.started)
+	<-b.release
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.LangfuseAPI.GetOrganization(ctx, orgID)
+}
+
+func TestCachedAPISharedReadSurvivesCancellation(t *testing.T) {
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(context.Background(), "team")
+	api := &blockingOrgs{LangfuseAPI: fake, started: make(chan struct{}), release: make(chan struct{})}
+	cached := client.NewCachedAPI(api)
+
+	// The first caller starts the read and gives up while it is in flight.
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error)
+	go func() {
+		_, err := cached.GetOrganization(first, org.ID)
+		firstErr <- err
+	}()
+	<-api.started
+
+	second := make(chan error)
+	go func() {
+		_, err := cached.GetOrganization(context.Background(), org.ID)
+		second <- err
+	}()
+	cancel()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled caller got %v, want context.Canceled", err)
+	}
+	close(api.release)
+	if err := <-second; err != nil {
+		t.Errorf("waiting caller failed with the first caller's cancellation: %v", err)
+	}
+}
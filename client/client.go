@@ -1,28 +1,313 @@
 package client
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/tls"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
-// Client is a Langfuse API client using the Admin API key.
+// unixScheme prefixes base URLs that address a Unix domain socket.
+const unixScheme = "unix://"
+
+const (
+	// maxResponseBodySize bounds how much of a response body the client reads.
+	maxResponseBodySize = 32 << 20
+	// maxErrorBodySize bounds how much of an error response is kept for messages.
+	maxErrorBodySize = 64 << 10
+	// maxDrainSize bounds how much of an unread response body is discarded to
+	// keep its connection reusable.
+	maxDrainSize = 64 << 10
+	// defaultMaxIdleConnsPerHost matches Terraform's default -parallelism.
+	defaultMaxIdleConnsPerHost = 10
+)
+
+// DefaultPageSize is the number of items requested per page by list calls.
+const DefaultPageSize = 50
+
+// Client is a Langfuse API client. Requests to the admin API use the Admin API
+// key; requests to the public API use an optional project key pair.
 type Client struct {
 	baseURL    string
 	adminKey   string
+	publicKey  string
+	secretKey  string
 	httpClient *http.Client
+	// uploadClient sends content to presigned storage URLs.
+	uploadClient *http.Client
+	transport    *http.Transport
+	dialer       *net.Dialer
+	pageSize     int
+	// sem limits the number of in-flight requests when non-nil.
+	sem chan struct{}
+	// hostHeader overrides the Host header of every request when set.
+	hostHeader string
+	retry      RetryPolicy
+	hooks      []Hook
+	// requestTimeout bounds each request attempt when positive.
+	requestTimeout time.Duration
+	// maxRedirects caps the redirects followed per request.
+	maxRedirects int
+	// metrics collects per-endpoint request statistics.
+	metrics metrics
+	// unknownFields selects how unknown response fields are handled;
+	// warnedFields remembers the ones already logged.
+	unknownFields UnknownFieldMode
+	warnedFields  sync.Map
+
+	// info caches the server version once it has been fetched.
+	infoMu sync.Mutex
+	info   *ServerInfo
+}
+
+// Option configures optional Client settings.
+type Option func(*Client)
+
+// WithPageSize sets the page size used by list calls. Values below 1 are ignored.
+func WithPageSize(size int) Option {
+	return func(c *Client) {
+		if size > 0 {
+			c.pageSize = size
+		}
+	}
+}
+
+// WithMaxConcurrentRequests limits how many API requests the client sends at
+// the same time. Values below 1 leave concurrency unlimited.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.sem = make(chan struct{}, n)
+		} else {
+			c.sem = nil
+		}
+	}
+}
+
+// WithHostHeader sends host as the Host header of every request instead of the
+// host from the base URL.
+func WithHostHeader(host string) Option {
+	return func(c *Client) {
+		c.hostHeader = host
+	}
+}
+
+// WithDialAddress makes the client connect to address (an IP or host, with an
+// optional port) instead of resolving the base URL host. TLS server name
+// verification still uses the host from the base URL.
+func WithDialAddress(address string) Option {
+	return func(c *Client) {
+		c.transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			target := address
+			if _, _, err := net.SplitHostPort(address); err != nil {
+				// No port in the override; keep the one derived from the base URL.
+				_, port, splitErr := net.SplitHostPort(addr)
+				if splitErr != nil {
+					return nil, splitErr
+				}
+				target = net.JoinHostPort(address, port)
+			}
+			return c.dialer.DialContext(ctx, network, target)
+		}
+	}
+}
+
+// WithKeepAlive sets the TCP keep-alive period of new connections. A negative
+// value disables TCP keep-alives.
+func WithKeepAlive(period time.Duration) Option {
+	return func(c *Client) {
+		c.dialer.KeepAlive = period
+	}
+}
+
+// WithDisableKeepAlives disables HTTP keep-alive so every request uses a fresh connection.
+func WithDisableKeepAlives(disable bool) Option {
+	return func(c *Client) {
+		c.transport.DisableKeepAlives = disable
+	}
+}
+
+// WithMaxIdleConnsPerHost sets how many idle connections are kept for reuse.
+func WithMaxIdleConnsPerHost(n int) Option {
+	return func(c *Client) {
+		c.transport.MaxIdleConnsPerHost = n
+		if c.transport.MaxIdleConns != 0 && c.transport.MaxIdleConns < n {
+			c.transport.MaxIdleConns = n
+		}
+	}
+}
+
+// WithIdleConnTimeout sets how long idle connections are kept before closing.
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.transport.IdleConnTimeout = timeout
+	}
+}
+
+// WithHTTP2 enables or disables HTTP/2 negotiation for TLS connections.
+func WithHTTP2(enabled bool) Option {
+	return func(c *Client) {
+		c.transport.ForceAttemptHTTP2 = enabled
+		if !enabled {
+			// A non-nil, empty map turns off the transport's automatic HTTP/2 support.
+			c.transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		} else {
+			c.transport.TLSNextProto = nil
+		}
+	}
+}
+
+// WithCompression controls whether responses are requested gzip-compressed.
+// When enabled (the default), the transport sends "Accept-Encoding: gzip" and
+// transparently decompresses responses, which cuts transfer time of large
+// list responses over slow links.
+func WithCompression(enabled bool) Option {
+	return func(c *Client) {
+		c.transport.DisableCompression = !enabled
+	}
+}
+
+// NewClient creates a new Langfuse Client with baseURL and adminKey. baseURL may
+// include a path prefix when Langfuse is served below a sub-path (e.g. behind a
+// reverse proxy at https://tools.example.com/langfuse), or have the form
+// unix:///path/to/socket to talk to an API listening on a Unix domain socket.
+func NewClient(baseURL, adminKey string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		adminKey:     adminKey,
+		transport:    http.DefaultTransport.(*http.Transport).Clone(),
+		dialer:       &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		pageSize:     DefaultPageSize,
+		retry:        DefaultRetryPolicy,
+		maxRedirects: DefaultMaxRedirects,
+	}
+	c.transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return c.dialer.DialContext(ctx, network, addr)
+	}
+	c.transport.DisableCompression = false
+	// net/http keeps only two idle connections per host by default, so with
+	// Terraform's default parallelism most requests would open a new one.
+	c.transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if socketPath, ok := strings.CutPrefix(baseURL, unixScheme); ok {
+		// Requests are addressed to a placeholder host; every connection goes
+		// to the socket regardless of the address derived from the URL.
+		c.baseURL = "http://localhost"
+		c.transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return c.dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	// Every attempt is logged, passed to the hooks, bounded by the request
+	// timeout and holds a request slot individually; retries wrap those
+	// layers, so no slot is held while waiting for the next attempt.
+	var rt http.RoundTripper = &loggingTransport{next: c.transport, logBodies: bodyLoggingEnabled()}
+	rt = &metricsTransport{next: rt, metrics: &c.metrics}
+	if len(c.hooks) > 0 {
+		rt = &hookTransport{next: rt, hooks: c.hooks}
+	}
+	if c.requestTimeout > 0 {
+		rt = &timeoutTransport{next: rt, timeout: c.requestTimeout}
+	}
+	if c.sem != nil {
+		rt = &limitTransport{next: rt, sem: c.sem}
+	}
+	rt = &retryTransport{next: rt, policy: c.retry}
+	c.httpClient = &http.Client{Transport: rt, CheckRedirect: checkRedirect(c.maxRedirects)}
+	// Presigned upload URLs point to the storage backend and carry their
+	// signature in the query, so uploads skip logging, metrics and hooks.
+	var upload http.RoundTripper = c.transport
+	if c.requestTimeout > 0 {
+		upload = &timeoutTransport{next: upload, timeout: c.requestTimeout}
+	}
+	if c.sem != nil {
+		upload = &limitTransport{next: upload, sem: c.sem}
+	}
+	upload = &retryTransport{next: upload, policy: c.retry}
+	c.uploadClient = &http.Client{Transport: upload, CheckRedirect: checkRedirect(c.maxRedirects)}
+	return c
+}
+
+// limitTransport is an http.RoundTripper that holds one of the request slots
+// in sem from the start of each attempt until its response body is closed.
+type limitTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, req.Context().Err()
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		<-t.sem
+		return nil, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: func() { <-t.sem }}
+	return resp, nil
+}
+
+// releaseOnClose frees a request slot once the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
 }
 
-// NewClient creates a new Langfuse Client with baseURL and adminKey.
-func NewClient(baseURL, adminKey string) *Client {
-	return &Client{
-		baseURL:    baseURL,
-		adminKey:   adminKey,
-		httpClient: &http.Client{},
+// Close implements io.Closer.
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// endpoint joins the base URL, including any path prefix, with an API path.
+func (c *Client) endpoint(apiPath string) string {
+	return c.baseURL + "/" + strings.TrimLeft(apiPath, "/")
+}
+
+// do sends req. Retries, logging and the concurrency limit are handled by the
+// client's transport chain; the request slot is held until the caller closes
+// the response body.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.hostHeader != "" {
+		req.Host = c.hostHeader
+	}
+	return c.httpClient.Do(req)
+}
+
+// readBody reads r completely, failing if it holds more than limit bytes.
+func readBody(r io.Reader, limit int64) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("response body exceeds %d bytes", limit)
 	}
+	return b, nil
+}
+
+// drainAndClose discards the unread rest of body, up to maxDrainSize bytes,
+// and closes it. A body closed before it was read to the end forces the
+// transport to drop the connection instead of returning it to the keep-alive
+// pool; bodies larger than the limit are cheaper to abandon than to read.
+func drainAndClose(body io.ReadCloser) error {
+	io.Copy(io.Discard, io.LimitReader(body, maxDrainSize))
+	return body.Close()
 }
 
 // Organization represents a Langfuse organization.
@@ -31,6 +316,17 @@ type Organization struct {
 	Name string `json:"name"`
 }
 
+// OrganizationUpdate holds the organization fields to change. Nil fields are
+// omitted from the request and left untouched by the API.
+type OrganizationUpdate struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u OrganizationUpdate) IsEmpty() bool {
+	return u.Name == nil
+}
+
 // Project represents a Langfuse project.
 type Project struct {
 	ID             string `json:"id"`
@@ -38,84 +334,109 @@ type Project struct {
 	OrganizationID string `json:"organizationId"`
 	PublicKey      string `json:"publicKey"`
 	SecretKey      string `json:"secretKey"`
+	// Metadata holds free-form key/value annotations, e.g. ownership tags.
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ProjectCreate holds the fields of a new project.
+type ProjectCreate struct {
+	Name     string         `json:"name"`
+	Metadata map[string]any `json:"metadata,omitempty"`
+}
+
+// ProjectUpdate holds the project fields to change. Nil fields are omitted
+// from the request and left untouched by the API. A non-nil Metadata replaces
+// the metadata as a whole; point it at an empty map to clear it.
+type ProjectUpdate struct {
+	Name     *string         `json:"name,omitempty"`
+	Metadata *map[string]any `json:"metadata,omitempty"`
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u ProjectUpdate) IsEmpty() bool {
+	return u.Name == nil && u.Metadata == nil
 }
 
 // CreateOrganization calls POST /api/admin/organizations.
 func (c *Client) CreateOrganization(ctx context.Context, name string) (*Organization, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations", c.baseURL)
-	body := map[string]string{"name": name}
-	data, _ := json.Marshal(body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(data))
+	req, err := c.newRequest(ctx, adminAPI, http.MethodPost, "/api/admin/organizations", map[string]string{"name": name})
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create organization failed: %s", string(b))
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, newAPIError("create organization", resp))
 	}
 	var org Organization
-	if err := json.NewDecoder(resp.Body).Decode(&org); err != nil {
+	if err := c.decodeJSON(ctx, "create organization", resp.Body, &org); err != nil {
 		return nil, err
 	}
 	return &org, nil
 }
 
-// GetOrganization calls GET /api/admin/organizations/{orgId}.
+// ListOrganizations calls GET /api/admin/organizations, following pages until
+// all organizations have been fetched.
+func (c *Client) ListOrganizations(ctx context.Context) ([]Organization, error) {
+	orgs, err := ListAll(ctx, c.pageSize, func(ctx context.Context, page, limit int) (Page[Organization], error) {
+		return fetchPage[Organization](ctx, c, adminAPI, "list organizations", "/api/admin/organizations", "organizations", page, limit)
+	})
+	if err != nil {
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, err)
+	}
+	return orgs, nil
+}
+
+// GetOrganization calls GET /api/admin/organizations/{orgId}. The returned error
+// matches ErrNotFound when the organization does not exist.
 func (c *Client) GetOrganization(ctx context.Context, orgID string) (*Organization, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s", c.baseURL, orgID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	apiPath, err := escapePath("/api/admin/organizations/%s", orgID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, adminAPI, http.MethodGet, apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("organization %s not found", orgID)
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get organization failed: %s", string(b))
+		return nil, newAPIError("get organization", resp)
 	}
 	var org Organization
-	if err := json.NewDecoder(resp.Body).Decode(&org); err != nil {
+	if err := c.decodeJSON(ctx, "get organization", resp.Body, &org); err != nil {
 		return nil, err
 	}
 	return &org, nil
 }
 
-// UpdateOrganization calls PUT /api/admin/organizations/{orgId}.
-func (c *Client) UpdateOrganization(ctx context.Context, orgID, name string) (*Organization, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s", c.baseURL, orgID)
-	body := map[string]string{"name": name}
-	data, _ := json.Marshal(body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(data))
+// UpdateOrganization calls PUT /api/admin/organizations/{orgId}, sending only
+// the fields set in update.
+func (c *Client) UpdateOrganization(ctx context.Context, orgID string, update OrganizationUpdate) (*Organization, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s", orgID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, adminAPI, http.MethodPut, apiPath, update)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update organization failed: %s", string(b))
+		return nil, newAPIError("update organization", resp)
 	}
 	var org Organization
-	if err := json.NewDecoder(resp.Body).Decode(&org); err != nil {
+	if err := c.decodeJSON(ctx, "update organization", resp.Body, &org); err != nil {
 		return nil, err
 	}
 	return &org, nil
@@ -123,100 +444,214 @@ func (c *Client) UpdateOrganization(ctx context.Context, orgID, name string) (*O
 
 // DeleteOrganization calls DELETE /api/admin/organizations/{orgId}.
 func (c *Client) DeleteOrganization(ctx context.Context, orgID string) error {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s", c.baseURL, orgID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	apiPath, err := escapePath("/api/admin/organizations/%s", orgID)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, adminAPI, http.MethodDelete, apiPath, nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("delete organization failed: %s", string(b))
+		return newAPIError("delete organization", resp)
 	}
 	return nil
 }
 
 // CreateProject calls POST /api/admin/organizations/{orgId}/projects.
-func (c *Client) CreateProject(ctx context.Context, orgID, name string) (*Project, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s/projects", c.baseURL, orgID)
-	body := map[string]string{"name": name}
-	data, _ := json.Marshal(body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(data))
+func (c *Client) CreateProject(ctx context.Context, orgID string, create ProjectCreate) (*Project, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects", orgID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, adminAPI, http.MethodPost, apiPath, create)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("create project failed: %s", string(b))
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, newAPIError("create project", resp))
 	}
 	var proj Project
-	if err := json.NewDecoder(resp.Body).Decode(&proj); err != nil {
+	if err := c.decodeJSON(ctx, "create project", resp.Body, &proj); err != nil {
 		return nil, err
 	}
 	return &proj, nil
 }
 
-// GetProject calls GET /api/admin/organizations/{orgId}/projects/{projId}.
+// ListProjects calls GET /api/admin/organizations/{orgId}/projects, following
+// pages until all projects of the organization have been fetched. Secret keys
+// are not included.
+func (c *Client) ListProjects(ctx context.Context, orgID string) ([]Project, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects", orgID)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := ListAll(ctx, c.pageSize, func(ctx context.Context, page, limit int) (Page[Project], error) {
+		return fetchPage[Project](ctx, c, adminAPI, "list projects", apiPath, "projects", page, limit)
+	})
+	if err != nil {
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, err)
+	}
+	return projects, nil
+}
+
+// APIKey describes a public/secret key pair of a project. SecretKey is only
+// set in the response to CreateProjectAPIKey; Langfuse never returns it again.
+type APIKey struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"publicKey"`
+	SecretKey string `json:"secretKey,omitempty"`
+	// DisplaySecretKey is a shortened form of the secret key, e.g. "sk-lf-...abcd".
+	DisplaySecretKey string `json:"displaySecretKey"`
+	Note             string `json:"note"`
+	CreatedAt        string `json:"createdAt"`
+	ExpiresAt        string `json:"expiresAt"`
+	LastUsedAt       string `json:"lastUsedAt"`
+}
+
+// ListProjectAPIKeys calls GET
+// /api/admin/organizations/{orgId}/projects/{projId}/apiKeys and returns the
+// API keys of the project.
+func (c *Client) ListProjectAPIKeys(ctx context.Context, orgID, projID string) ([]APIKey, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s/apiKeys", orgID, projID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, adminAPI, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, newAPIError("list project API keys", resp))
+	}
+	var body struct {
+		APIKeys []APIKey `json:"apiKeys"`
+	}
+	if err := c.decodeJSON(ctx, "list project API keys", resp.Body, &body); err != nil {
+		return nil, err
+	}
+	return body.APIKeys, nil
+}
+
+// APIKeyCreate holds the fields of a new API key.
+type APIKeyCreate struct {
+	// Note documents the purpose of the key, e.g. the service using it.
+	Note string `json:"note,omitempty"`
+}
+
+// CreateProjectAPIKey calls POST
+// /api/admin/organizations/{orgId}/projects/{projId}/apiKeys and returns the
+// new key pair, including its secret key.
+func (c *Client) CreateProjectAPIKey(ctx context.Context, orgID, projID string, create APIKeyCreate) (*APIKey, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s/apiKeys", orgID, projID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, adminAPI, http.MethodPost, apiPath, create)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, c.explainNotFound(ctx, CapabilityOrganizationManagement, newAPIError("create project API key", resp))
+	}
+	var key APIKey
+	if err := c.decodeJSON(ctx, "create project API key", resp.Body, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// DeleteProjectAPIKey calls DELETE
+// /api/admin/organizations/{orgId}/projects/{projId}/apiKeys/{apiKeyId},
+// revoking the key. The returned error matches ErrNotFound when the key does
+// not exist.
+func (c *Client) DeleteProjectAPIKey(ctx context.Context, orgID, projID, keyID string) error {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s/apiKeys/%s", orgID, projID, keyID)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, adminAPI, http.MethodDelete, apiPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return c.explainNotFound(ctx, CapabilityOrganizationManagement, newAPIError("delete project API key", resp))
+	}
+	return nil
+}
+
+// GetProject calls GET /api/admin/organizations/{orgId}/projects/{projId}. The
+// returned error matches ErrNotFound when the project does not exist.
 func (c *Client) GetProject(ctx context.Context, orgID, projID string) (*Project, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s/projects/%s", c.baseURL, orgID, projID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s", orgID, projID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, adminAPI, http.MethodGet, apiPath, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return nil, fmt.Errorf("project %s not found", projID)
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("get project failed: %s", string(b))
+		return nil, newAPIError("get project", resp)
 	}
 	var proj Project
-	if err := json.NewDecoder(resp.Body).Decode(&proj); err != nil {
+	if err := c.decodeJSON(ctx, "get project", resp.Body, &proj); err != nil {
 		return nil, err
 	}
 	return &proj, nil
 }
 
-// UpdateProject calls PUT /api/admin/organizations/{orgId}/projects/{projId}.
-func (c *Client) UpdateProject(ctx context.Context, orgID, projID, name string) (*Project, error) {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s/projects/%s", c.baseURL, orgID, projID)
-	body := map[string]string{"name": name}
-	data, _ := json.Marshal(body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewBuffer(data))
+// UpdateProject calls PUT /api/admin/organizations/{orgId}/projects/{projId},
+// sending only the fields set in update.
+func (c *Client) UpdateProject(ctx context.Context, orgID, projID string, update ProjectUpdate) (*Project, error) {
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s", orgID, projID)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := c.httpClient.Do(req)
+	req, err := c.newRequest(ctx, adminAPI, http.MethodPut, apiPath, update)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("update project failed: %s", string(b))
+		return nil, newAPIError("update project", resp)
 	}
 	var proj Project
-	if err := json.NewDecoder(resp.Body).Decode(&proj); err != nil {
+	if err := c.decodeJSON(ctx, "update project", resp.Body, &proj); err != nil {
 		return nil, err
 	}
 	return &proj, nil
@@ -224,20 +659,21 @@ func (c *Client) UpdateProject(ctx context.Context, orgID, projID, name string)
 
 // DeleteProject calls DELETE /api/admin/organizations/{orgId}/projects/{projId}.
 func (c *Client) DeleteProject(ctx context.Context, orgID, projID string) error {
-	url := fmt.Sprintf("%s/api/admin/organizations/%s/projects/%s", c.baseURL, orgID, projID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	apiPath, err := escapePath("/api/admin/organizations/%s/projects/%s", orgID, projID)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, adminAPI, http.MethodDelete, apiPath, nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.adminKey)
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	defer drainAndClose(resp.Body)
 	if resp.StatusCode >= 300 {
-		b, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("delete project failed: %s", string(b))
+		return newAPIError("delete project", resp)
 	}
 	return nil
 }
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimitHoldsSlotUntilBodyClosed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "admin-key", WithMaxConcurrentRequests(1))
+	get := func(ctx context.Context) (*http.Response, error) {
+		req, err := c.newRequest(ctx, adminAPI, http.MethodGet, "/api/admin/organizations", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.do(req)
+	}
+
+	first, err := get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second request with the first body open: got %v, want a deadline error", err)
+	}
+
+	drainAndClose(first.Body)
+	second, err := get(context.Background())
+	if err != nil {
+		t.Fatalf("second request after closing the first body: %v", err)
+	}
+	drainAndClose(second.Body)
+}
+
+func TestConcurrencyLimitReleasesSlotDuringBackoff(t *testing.T) {
+	var failed atomic.Bool
+	waiting := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/public/health" && !failed.Swap(true) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			close(waiting)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","version":"3.0.0"}`))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "admin-key", WithMaxConcurrentRequests(1),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, MinBackoff: time.Second, MaxBackoff: time.Second}))
+
+	retried := make(chan error, 1)
+	go func() {
+		_, err := c.ServerInfo(context.Background())
+		retried <- err
+	}()
+	<-waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if _, err := c.ListOrganizations(ctx); err != nil {
+		t.Errorf("request during the backoff of another: %v", err)
+	}
+	if err := <-retried; err != nil {
+		t.Errorf("retried request: %v", err)
+	}
+}
+
+func TestConcurrencyLimitAllowsExplainingErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/public/health" {
+			w.Write([]byte(`{"status":"OK","version":"2.0.0"}`))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "admin-key", WithMaxConcurrentRequests(1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := c.CreateOrganization(ctx, "acme")
+	var unsupported *UnsupportedError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("CreateOrganization = %v, want an UnsupportedError", err)
+	}
+}
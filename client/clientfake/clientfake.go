@@ -0,0 +1,460 @@
+// Package clientfake provides an in-memory implementation of
+// client.LangfuseAPI for unit tests.
+package clientfake
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+)
+
+// Fake is an in-memory Langfuse API. The zero value is not usable; create
+// instances with New. All methods are safe for concurrent use.
+type Fake struct {
+	mu       sync.Mutex
+	nextID   int
+	orgs     map[string]*client.Organization
+	projects map[string]*client.Project
+	prompts  []client.Prompt
+	runs     []client.DatasetRun
+	queues   map[string][]client.AnnotationQueueItem
+	// keys holds the API keys of each project, secret keys included.
+	keys map[string][]client.APIKey
+
+	// Err, when set, is returned by every method instead of performing the
+	// operation, to simulate API failures.
+	Err error
+}
+
+var _ client.LangfuseAPI = (*Fake)(nil)
+
+// New returns an empty Fake.
+func New() *Fake {
+	return &Fake{
+		orgs:     map[string]*client.Organization{},
+		projects: map[string]*client.Project{},
+		queues:   map[string][]client.AnnotationQueueItem{},
+		keys:     map[string][]client.APIKey{},
+	}
+}
+
+// notFound returns an error that matches client.ErrNotFound.
+func notFound(operation, method, path string) error {
+	return &client.APIError{
+		Operation:  operation,
+		StatusCode: http.StatusNotFound,
+		Method:     method,
+		Path:       path,
+		Message:    "not found",
+	}
+}
+
+// newID returns a fresh identifier with the given prefix. Callers hold f.mu.
+func (f *Fake) newID(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s-%d", prefix, f.nextID)
+}
+
+// ListOrganizations implements client.LangfuseAPI, returning organizations sorted by ID.
+func (f *Fake) ListOrganizations(ctx context.Context) ([]client.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	orgs := make([]client.Organization, 0, len(f.orgs))
+	for _, org := range f.orgs {
+		orgs = append(orgs, *org)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].ID < orgs[j].ID })
+	return orgs, nil
+}
+
+// CreateOrganization implements client.LangfuseAPI.
+func (f *Fake) CreateOrganization(ctx context.Context, name string) (*client.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	org := &client.Organization{ID: f.newID("org"), Name: name}
+	f.orgs[org.ID] = org
+	out := *org
+	return &out, nil
+}
+
+// GetOrganization implements client.LangfuseAPI.
+func (f *Fake) GetOrganization(ctx context.Context, orgID string) (*client.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	org, ok := f.orgs[orgID]
+	if !ok {
+		return nil, notFound("get organization", http.MethodGet, "/api/admin/organizations/"+orgID)
+	}
+	out := *org
+	return &out, nil
+}
+
+// UpdateOrganization implements client.LangfuseAPI.
+func (f *Fake) UpdateOrganization(ctx context.Context, orgID string, update client.OrganizationUpdate) (*client.Organization, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	org, ok := f.orgs[orgID]
+	if !ok {
+		return nil, notFound("update organization", http.MethodPut, "/api/admin/organizations/"+orgID)
+	}
+	if update.Name != nil {
+		org.Name = *update.Name
+	}
+	out := *org
+	return &out, nil
+}
+
+// DeleteOrganization implements client.LangfuseAPI. Projects of the
+// organization are deleted with it.
+func (f *Fake) DeleteOrganization(ctx context.Context, orgID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	if _, ok := f.orgs[orgID]; !ok {
+		return notFound("delete organization", http.MethodDelete, "/api/admin/organizations/"+orgID)
+	}
+	delete(f.orgs, orgID)
+	for id, proj := range f.projects {
+		if proj.OrganizationID == orgID {
+			delete(f.projects, id)
+			delete(f.keys, id)
+		}
+	}
+	return nil
+}
+
+// ListProjects implements client.LangfuseAPI, returning the projects of the
+// organization sorted by ID and without their secret keys.
+func (f *Fake) ListProjects(ctx context.Context, orgID string) ([]client.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if _, ok := f.orgs[orgID]; !ok {
+		return nil, notFound("list projects", http.MethodGet, "/api/admin/organizations/"+orgID+"/projects")
+	}
+	var projects []client.Project
+	for _, proj := range f.projects {
+		if proj.OrganizationID != orgID {
+			continue
+		}
+		out := *proj
+		out.Metadata = maps.Clone(proj.Metadata)
+		out.SecretKey = ""
+		projects = append(projects, out)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects, nil
+}
+
+// CreateProject implements client.LangfuseAPI. The returned project carries a
+// generated key pair.
+func (f *Fake) CreateProject(ctx context.Context, orgID string, create client.ProjectCreate) (*client.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if _, ok := f.orgs[orgID]; !ok {
+		return nil, notFound("create project", http.MethodPost, "/api/admin/organizations/"+orgID+"/projects")
+	}
+	proj := &client.Project{
+		ID:             f.newID("proj"),
+		Name:           create.Name,
+		OrganizationID: orgID,
+		Metadata:       maps.Clone(create.Metadata),
+	}
+	proj.PublicKey = "pk-lf-" + proj.ID
+	proj.SecretKey = "sk-lf-" + proj.ID
+	f.projects[proj.ID] = proj
+	f.keys[proj.ID] = []client.APIKey{{
+		ID:               "key-" + proj.ID,
+		PublicKey:        proj.PublicKey,
+		SecretKey:        proj.SecretKey,
+		DisplaySecretKey: displaySecretKey(proj.SecretKey),
+		CreatedAt:        now(),
+	}}
+	out := *proj
+	out.Metadata = maps.Clone(proj.Metadata)
+	return &out, nil
+}
+
+// project looks up a project of the given organization. Callers hold f.mu.
+func (f *Fake) project(orgID, projID string) (*client.Project, bool) {
+	proj, ok := f.projects[projID]
+	if !ok || proj.OrganizationID != orgID {
+		return nil, false
+	}
+	return proj, true
+}
+
+// GetProject implements client.LangfuseAPI. Like the API, it does not return
+// the secret key.
+func (f *Fake) GetProject(ctx context.Context, orgID, projID string) (*client.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	proj, ok := f.project(orgID, projID)
+	if !ok {
+		return nil, notFound("get project", http.MethodGet, "/api/admin/organizations/"+orgID+"/projects/"+projID)
+	}
+	out := *proj
+	out.Metadata = maps.Clone(proj.Metadata)
+	out.SecretKey = ""
+	return &out, nil
+}
+
+// UpdateProject implements client.LangfuseAPI.
+func (f *Fake) UpdateProject(ctx context.Context, orgID, projID string, update client.ProjectUpdate) (*client.Project, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	proj, ok := f.project(orgID, projID)
+	if !ok {
+		return nil, notFound("update project", http.MethodPut, "/api/admin/organizations/"+orgID+"/projects/"+projID)
+	}
+	if update.Name != nil {
+		proj.Name = *update.Name
+	}
+	if update.Metadata != nil {
+		proj.Metadata = maps.Clone(*update.Metadata)
+		if len(proj.Metadata) == 0 {
+			proj.Metadata = nil
+		}
+	}
+	out := *proj
+	out.Metadata = maps.Clone(proj.Metadata)
+	out.SecretKey = ""
+	return &out, nil
+}
+
+// DeleteProject implements client.LangfuseAPI.
+func (f *Fake) DeleteProject(ctx context.Context, orgID, projID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	if _, ok := f.project(orgID, projID); !ok {
+		return notFound("delete project", http.MethodDelete, "/api/admin/organizations/"+orgID+"/projects/"+projID)
+	}
+	delete(f.projects, projID)
+	delete(f.keys, projID)
+	return nil
+}
+
+// ListProjectAPIKeys implements client.LangfuseAPI. Like the API, it does
+// not return secret keys.
+func (f *Fake) ListProjectAPIKeys(ctx context.Context, orgID, projID string) ([]client.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if _, ok := f.project(orgID, projID); !ok {
+		return nil, notFound("list project API keys", http.MethodGet, "/api/admin/organizations/"+orgID+"/projects/"+projID+"/apiKeys")
+	}
+	keys := slices.Clone(f.keys[projID])
+	for i := range keys {
+		keys[i].SecretKey = ""
+	}
+	return keys, nil
+}
+
+// CreateProjectAPIKey implements client.LangfuseAPI.
+func (f *Fake) CreateProjectAPIKey(ctx context.Context, orgID, projID string, create client.APIKeyCreate) (*client.APIKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	if _, ok := f.project(orgID, projID); !ok {
+		return nil, notFound("create project API key", http.MethodPost, "/api/admin/organizations/"+orgID+"/projects/"+projID+"/apiKeys")
+	}
+	id := f.newID("key")
+	key := client.APIKey{
+		ID:        id,
+		PublicKey: "pk-lf-" + id,
+		SecretKey: "sk-lf-" + id,
+		Note:      create.Note,
+		CreatedAt: now(),
+	}
+	key.DisplaySecretKey = displaySecretKey(key.SecretKey)
+	f.keys[projID] = append(f.keys[projID], key)
+	return &key, nil
+}
+
+// DeleteProjectAPIKey implements client.LangfuseAPI.
+func (f *Fake) DeleteProjectAPIKey(ctx context.Context, orgID, projID, keyID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	keys := f.keys[projID]
+	i := slices.IndexFunc(keys, func(k client.APIKey) bool { return k.ID == keyID })
+	if _, ok := f.project(orgID, projID); !ok || i < 0 {
+		return notFound("delete project API key", http.MethodDelete, "/api/admin/organizations/"+orgID+"/projects/"+projID+"/apiKeys/"+keyID)
+	}
+	f.keys[projID] = slices.Delete(keys, i, i+1)
+	return nil
+}
+
+// SetAPIKeyExpiry sets the expiry time of an API key of project projID. The
+// API cannot set it, so the fake has no other way to obtain expiring keys.
+func (f *Fake) SetAPIKeyExpiry(projID, keyID, expiresAt string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.keys[projID] {
+		if f.keys[projID][i].ID == keyID {
+			f.keys[projID][i].ExpiresAt = expiresAt
+		}
+	}
+}
+
+// displaySecretKey shortens a secret key like the API does for listings.
+func displaySecretKey(key string) string {
+	return "sk-lf-..." + key[max(len(key)-4, 0):]
+}
+
+// now returns the current time in the format used by the API.
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// AddPrompt stores a prompt version for GetPrompt. Prompts are created outside
+// of Terraform, so the fake has no other way to obtain them.
+func (f *Fake) AddPrompt(p client.Prompt) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prompts = append(f.prompts, p)
+}
+
+// GetPrompt implements client.LangfuseAPI. Without a selector it returns the
+// version labeled "production", like the API.
+func (f *Fake) GetPrompt(ctx context.Context, name string, sel client.PromptSelector) (*client.Prompt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	label := sel.Label
+	if label == "" && sel.Version == 0 {
+		label = "production"
+	}
+	for _, p := range f.prompts {
+		if p.Name != name || (sel.Version != 0 && p.Version != sel.Version) {
+			continue
+		}
+		if label != "" && !slices.Contains(p.Labels, label) {
+			continue
+		}
+		out := p
+		return &out, nil
+	}
+	return nil, notFound("get prompt", http.MethodGet, "/api/public/v2/prompts/"+name)
+}
+
+// SetPromptLabels implements client.LangfuseAPI, moving the labels from the
+// other versions of the prompt like the API.
+func (f *Fake) SetPromptLabels(ctx context.Context, name string, version int, labels []string) (*client.Prompt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	i := slices.IndexFunc(f.prompts, func(p client.Prompt) bool { return p.Name == name && p.Version == version })
+	if i < 0 {
+		return nil, notFound("set prompt labels", http.MethodPatch, fmt.Sprintf("/api/public/v2/prompts/%s/versions/%d", name, version))
+	}
+	for j := range f.prompts {
+		if f.prompts[j].Name == name {
+			f.prompts[j].Labels = slices.DeleteFunc(slices.Clone(f.prompts[j].Labels), func(l string) bool { return slices.Contains(labels, l) })
+		}
+	}
+	f.prompts[i].Labels = append(f.prompts[i].Labels, labels...)
+	out := f.prompts[i]
+	out.Labels = slices.Clone(out.Labels)
+	return &out, nil
+}
+
+// AddDatasetRun stores a dataset run for GetDatasetRun. Like prompts, dataset
+// runs are created outside of Terraform.
+func (f *Fake) AddDatasetRun(run client.DatasetRun) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs = append(f.runs, run)
+}
+
+// GetDatasetRun implements client.LangfuseAPI.
+func (f *Fake) GetDatasetRun(ctx context.Context, datasetName, runName string) (*client.DatasetRun, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	for _, run := range f.runs {
+		if run.DatasetName == datasetName && run.Name == runName {
+			out := run
+			out.Metadata = slices.Clone(run.Metadata)
+			out.DatasetRunItems = slices.Clone(run.DatasetRunItems)
+			return &out, nil
+		}
+	}
+	return nil, notFound("get dataset run", http.MethodGet, "/api/public/datasets/"+datasetName+"/runs/"+runName)
+}
+
+// AddAnnotationQueueItem stores an item of the annotation queue item.QueueID,
+// creating the queue if needed.
+func (f *Fake) AddAnnotationQueueItem(item client.AnnotationQueueItem) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queues[item.QueueID] = append(f.queues[item.QueueID], item)
+}
+
+// ListAnnotationQueueItems implements client.LangfuseAPI, returning the items
+// in the order they were added.
+func (f *Fake) ListAnnotationQueueItems(ctx context.Context, queueID string, status client.AnnotationQueueStatus) ([]client.AnnotationQueueItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return nil, f.Err
+	}
+	queue, ok := f.queues[queueID]
+	if !ok {
+		return nil, notFound("list annotation queue items", http.MethodGet, "/api/public/annotation-queues/"+queueID+"/items")
+	}
+	var items []client.AnnotationQueueItem
+	for _, item := range queue {
+		if status == "" || item.Status == status {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,204 @@
+package clientfake
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+)
+
+// ServerVersion is the Langfuse version reported by the health endpoint of
+// servers created by NewServer.
+const ServerVersion = "3.0.0"
+
+// NewServer starts an HTTP server serving the parts of the Langfuse admin
+// and public APIs used by the provider from f, so the provider can be tested
+// end to end without a Langfuse instance. Admin endpoints expect a bearer
+// token and public endpoints basic auth; the credentials themselves are not
+// checked. The caller must close the server.
+func NewServer(f *Fake) *httptest.Server {
+	mux := http.NewServeMux()
+	admin := func(pattern string, h http.HandlerFunc) { mux.Handle(pattern, requireAuth("Bearer ", h)) }
+	public := func(pattern string, h http.HandlerFunc) { mux.Handle(pattern, requireAuth("Basic ", h)) }
+
+	mux.HandleFunc("GET /api/public/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, client.ServerInfo{Status: "OK", Version: ServerVersion})
+	})
+
+	admin("GET /api/admin/organizations", func(w http.ResponseWriter, r *http.Request) {
+		orgs, err := f.ListOrganizations(r.Context())
+		writePage(w, r, "organizations", orgs, err)
+	})
+	admin("POST /api/admin/organizations", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		org, err := f.CreateOrganization(r.Context(), body.Name)
+		writeResult(w, http.StatusCreated, org, err)
+	})
+	admin("GET /api/admin/organizations/{orgId}", func(w http.ResponseWriter, r *http.Request) {
+		org, err := f.GetOrganization(r.Context(), r.PathValue("orgId"))
+		writeResult(w, http.StatusOK, org, err)
+	})
+	admin("PUT /api/admin/organizations/{orgId}", func(w http.ResponseWriter, r *http.Request) {
+		var update client.OrganizationUpdate
+		if !readJSON(w, r, &update) {
+			return
+		}
+		org, err := f.UpdateOrganization(r.Context(), r.PathValue("orgId"), update)
+		writeResult(w, http.StatusOK, org, err)
+	})
+	admin("DELETE /api/admin/organizations/{orgId}", func(w http.ResponseWriter, r *http.Request) {
+		err := f.DeleteOrganization(r.Context(), r.PathValue("orgId"))
+		writeResult(w, http.StatusOK, map[string]bool{"success": true}, err)
+	})
+
+	admin("GET /api/admin/organizations/{orgId}/projects", func(w http.ResponseWriter, r *http.Request) {
+		projects, err := f.ListProjects(r.Context(), r.PathValue("orgId"))
+		writePage(w, r, "projects", projects, err)
+	})
+	admin("POST /api/admin/organizations/{orgId}/projects", func(w http.ResponseWriter, r *http.Request) {
+		var create client.ProjectCreate
+		if !readJSON(w, r, &create) {
+			return
+		}
+		proj, err := f.CreateProject(r.Context(), r.PathValue("orgId"), create)
+		writeResult(w, http.StatusCreated, proj, err)
+	})
+	admin("GET /api/admin/organizations/{orgId}/projects/{projId}", func(w http.ResponseWriter, r *http.Request) {
+		proj, err := f.GetProject(r.Context(), r.PathValue("orgId"), r.PathValue("projId"))
+		writeResult(w, http.StatusOK, proj, err)
+	})
+	admin("PUT /api/admin/organizations/{orgId}/projects/{projId}", func(w http.ResponseWriter, r *http.Request) {
+		var update client.ProjectUpdate
+		if !readJSON(w, r, &update) {
+			return
+		}
+		proj, err := f.UpdateProject(r.Context(), r.PathValue("orgId"), r.PathValue("projId"), update)
+		writeResult(w, http.StatusOK, proj, err)
+	})
+	admin("DELETE /api/admin/organizations/{orgId}/projects/{projId}", func(w http.ResponseWriter, r *http.Request) {
+		err := f.DeleteProject(r.Context(), r.PathValue("orgId"), r.PathValue("projId"))
+		writeResult(w, http.StatusOK, map[string]bool{"success": true}, err)
+	})
+	admin("GET /api/admin/organizations/{orgId}/projects/{projId}/apiKeys", func(w http.ResponseWriter, r *http.Request) {
+		keys, err := f.ListProjectAPIKeys(r.Context(), r.PathValue("orgId"), r.PathValue("projId"))
+		writeResult(w, http.StatusOK, map[string]any{"apiKeys": keys}, err)
+	})
+	admin("POST /api/admin/organizations/{orgId}/projects/{projId}/apiKeys", func(w http.ResponseWriter, r *http.Request) {
+		var body client.APIKeyCreate
+		if !readJSON(w, r, &body) {
+			return
+		}
+		key, err := f.CreateProjectAPIKey(r.Context(), r.PathValue("orgId"), r.PathValue("projId"), body)
+		writeResult(w, http.StatusCreated, key, err)
+	})
+	admin("DELETE /api/admin/organizations/{orgId}/projects/{projId}/apiKeys/{keyId}", func(w http.ResponseWriter, r *http.Request) {
+		err := f.DeleteProjectAPIKey(r.Context(), r.PathValue("orgId"), r.PathValue("projId"), r.PathValue("keyId"))
+		writeResult(w, http.StatusOK, map[string]bool{"success": true}, err)
+	})
+
+	public("GET /api/public/v2/prompts/{name}", func(w http.ResponseWriter, r *http.Request) {
+		sel := client.PromptSelector{Label: r.URL.Query().Get("label")}
+		if v := r.URL.Query().Get("version"); v != "" {
+			version, err := strconv.Atoi(v)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "version must be an integer")
+				return
+			}
+			sel.Version = version
+		}
+		p, err := f.GetPrompt(r.Context(), r.PathValue("name"), sel)
+		writeResult(w, http.StatusOK, p, err)
+	})
+	public("GET /api/public/datasets/{name}/runs/{run}", func(w http.ResponseWriter, r *http.Request) {
+		run, err := f.GetDatasetRun(r.Context(), r.PathValue("name"), r.PathValue("run"))
+		writeResult(w, http.StatusOK, run, err)
+	})
+	public("GET /api/public/annotation-queues/{queueId}/items", func(w http.ResponseWriter, r *http.Request) {
+		status := client.AnnotationQueueStatus(r.URL.Query().Get("status"))
+		items, err := f.ListAnnotationQueueItems(r.Context(), r.PathValue("queueId"), status)
+		writePage(w, r, "data", items, err)
+	})
+
+	return httptest.NewServer(mux)
+}
+
+// requireAuth rejects requests whose Authorization header lacks the given
+// scheme prefix.
+func requireAuth(scheme string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), scheme) {
+			writeError(w, http.StatusUnauthorized, "missing "+strings.TrimSpace(scheme)+" credentials")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readJSON decodes the request body into v, answering 400 if it is invalid.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writePage answers a list request with the page and limit given in the
+// query, defaulting to all items on one page.
+func writePage[T any](w http.ResponseWriter, r *http.Request, itemsKey string, items []T, err error) {
+	if err != nil {
+		writeResult(w, http.StatusOK, nil, err)
+		return
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page, limit = max(page, 1), max(limit, 1)
+	if r.URL.Query().Get("limit") == "" {
+		limit = max(len(items), 1)
+	}
+	start := min((page-1)*limit, len(items))
+	end := min(start+limit, len(items))
+	writeJSON(w, http.StatusOK, map[string]any{
+		itemsKey: append([]T{}, items[start:end]...),
+		"meta": client.PageMeta{
+			Page:       page,
+			Limit:      limit,
+			TotalItems: len(items),
+			TotalPages: max((len(items)+limit-1)/limit, 1),
+		},
+	})
+}
+
+// writeResult answers with v, or with the status and message of err.
+func writeResult(w http.ResponseWriter, status int, v any, err error) {
+	var apiErr *client.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		writeError(w, apiErr.StatusCode, apiErr.Message)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, status, v)
+	}
+}
+
+// writeError answers with a Langfuse style error body.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+// writeJSON answers with v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
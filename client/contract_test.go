@@ -0,0 +1,288 @@
+package client_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+)
+
+// contractSpecPath returns the Langfuse OpenAPI specification to test against:
+// LANGFUSE_OPENAPI_SPEC if set, otherwise the copy in testdata.
+func contractSpecPath() string {
+	if p := os.Getenv("LANGFUSE_OPENAPI_SPEC"); p != "" {
+		return p
+	}
+	return "testdata/openapi.yml"
+}
+
+// contractAdminSpecEnvVar names an OpenAPI document describing the
+// self-hosted admin API, e.g. one generated from the Langfuse source. The
+// published specification does not cover that API, so the admin operations
+// are only checked when it is set. Its paths and security schemes are added
+// to those of the main specification.
+const contractAdminSpecEnvVar = "LANGFUSE_ADMIN_OPENAPI_SPEC"
+
+// loadContractSpec loads the specification to test against, extended by the
+// admin specification if one is configured.
+func loadContractSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := openapi3.NewLoader().LoadFromFile(contractSpecPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := os.Getenv(contractAdminSpecEnvVar)
+	if p == "" {
+		return doc
+	}
+	admin, err := openapi3.NewLoader().LoadFromFile(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, item := range admin.Paths.Map() {
+		if doc.Paths.Value(path) == nil {
+			doc.Paths.Set(path, item)
+		}
+	}
+	// References are resolved on load; only the security schemes are looked
+	// up by name while validating.
+	if doc.Components.SecuritySchemes == nil {
+		doc.Components.SecuritySchemes = openapi3.SecuritySchemes{}
+	}
+	for name, scheme := range admin.Components.SecuritySchemes {
+		if _, ok := doc.Components.SecuritySchemes[name]; !ok {
+			doc.Components.SecuritySchemes[name] = scheme
+		}
+	}
+	return doc
+}
+
+// errNotInSpec marks requests to operations the specification does not
+// describe, such as the self-hosted admin API in some releases.
+var errNotInSpec = errors.New("operation not described by the specification")
+
+// contractServer answers the client with canned responses after checking
+// that both the request and the response conform to the specification.
+type contractServer struct {
+	router routers.Router
+
+	mu       sync.Mutex
+	status   int
+	body     string
+	problems []error
+}
+
+func (s *contractServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Presigned media uploads go to the storage backend, not the API.
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := context.Background()
+	route, params, err := s.router.FindRoute(r)
+	for _, v := range params {
+		// The router also matches a missing trailing path parameter.
+		if v == "" {
+			err = routers.ErrPathNotFound
+		}
+	}
+	if err != nil {
+		s.problems = append(s.problems, fmt.Errorf("%w: %s %s", errNotInSpec, r.Method, r.URL.Path))
+		w.WriteHeader(http.StatusNotImplemented)
+		return
+	}
+	opts := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		MultiError:         true,
+	}
+	reqInput := &openapi3filter.RequestValidationInput{Request: r, PathParams: params, Route: route, Options: opts}
+	if err := openapi3filter.ValidateRequest(ctx, reqInput); err != nil {
+		s.problems = append(s.problems, fmt.Errorf("request %s %s: %w", r.Method, r.URL.Path, err))
+	}
+
+	header := http.Header{"Content-Type": []string{"application/json"}}
+	respInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: reqInput,
+		Status:                 s.status,
+		Header:                 header,
+		Options:                opts,
+	}
+	respInput.SetBodyBytes([]byte(s.body))
+	if err := openapi3filter.ValidateResponse(ctx, respInput); err != nil {
+		s.problems = append(s.problems, fmt.Errorf("response of %s %s: %w", r.Method, r.URL.Path, err))
+	}
+
+	for k, v := range header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(s.status)
+	w.Write([]byte(s.body))
+}
+
+// reset prepares the server for the next operation and returns the problems
+// found while serving the previous one.
+func (s *contractServer) reset(status int, body string) []error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	problems := s.problems
+	s.status, s.body, s.problems = status, body, nil
+	return problems
+}
+
+// TestContract checks the requests sent by the client, and the responses it
+// is able to decode, against the Langfuse OpenAPI specification. It catches
+// payload drift when the upstream API evolves. testdata holds a pinned copy of
+// the published operations the client uses; run go generate ./client to check
+// against the current upstream document instead. Operations missing from the
+// specification, like the admin API unless LANGFUSE_ADMIN_OPENAPI_SPEC is
+// set, are skipped rather than checked against a description derived from
+// the client itself.
+func TestContract(t *testing.T) {
+	doc := loadContractSpec(t)
+
+	// Match requests by path alone rather than against the hosted servers.
+	doc.Servers = nil
+	srv := &contractServer{}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	router, err := legacy.NewRouter(doc,
+		openapi3.DisableExamplesValidation(),
+		openapi3.DisableSchemaDefaultsValidation(),
+		openapi3.DisableSchemaPatternValidation(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.router = router
+
+	c := client.NewClient(ts.URL, "admin-key",
+		client.WithPublicAPICredentials("pk-lf-test", "sk-lf-test"),
+		client.WithRetryPolicy(client.RetryPolicy{}),
+	)
+	org := `{"id":"org-1","name":"team"}`
+	project := `{"id":"proj-1","name":"search","organizationId":"org-1","publicKey":"pk-lf-1","secretKey":"sk-lf-1","metadata":{"team":"search"}}`
+	prompt := `{"id":"prompt-1","name":"greeting","version":2,"type":"text","prompt":"Hello {{name}}","config":{},"labels":["production"],"tags":[],"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z","createdBy":"user-1","projectId":"proj-1","isActive":null,"commitMessage":null,"resolutionGraph":null}`
+	run := `{"id":"run-1","name":"nightly","description":null,"metadata":{"commit":"abc123"},"datasetId":"dataset-1","datasetName":"qa","createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z","datasetRunItems":[{"id":"item-run-1","datasetRunId":"run-1","datasetRunName":"nightly","datasetItemId":"item-1","traceId":"trace-1","observationId":null,"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z"}]}`
+	media := `{"mediaId":"media-1","contentType":"image/png","contentLength":4,"uploadedAt":"2025-01-01T00:00:00.000Z","url":"https://example.com/media-1","urlExpiry":"2025-01-01T01:00:00.000Z"}`
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		call   func(ctx context.Context) error
+	}{
+		{"list organizations", 200, `{"organizations":[` + org + `]}`, func(ctx context.Context) error {
+			_, err := c.ListOrganizations(ctx)
+			return err
+		}},
+		{"create organization", 201, org, func(ctx context.Context) error {
+			_, err := c.CreateOrganization(ctx, "team")
+			return err
+		}},
+		{"get organization", 200, org, func(ctx context.Context) error {
+			_, err := c.GetOrganization(ctx, "org-1")
+			return err
+		}},
+		{"update organization", 200, org, func(ctx context.Context) error {
+			name := "team"
+			_, err := c.UpdateOrganization(ctx, "org-1", client.OrganizationUpdate{Name: &name})
+			return err
+		}},
+		{"delete organization", 200, `{"success":true}`, func(ctx context.Context) error {
+			return c.DeleteOrganization(ctx, "org-1")
+		}},
+		{"list projects", 200, `{"projects":[` + project + `]}`, func(ctx context.Context) error {
+			_, err := c.ListProjects(ctx, "org-1")
+			return err
+		}},
+		{"create project", 201, project, func(ctx context.Context) error {
+			_, err := c.CreateProject(ctx, "org-1", client.ProjectCreate{Name: "search", Metadata: map[string]any{"team": "search"}})
+			return err
+		}},
+		{"get project", 200, project, func(ctx context.Context) error {
+			_, err := c.GetProject(ctx, "org-1", "proj-1")
+			return err
+		}},
+		{"update project", 200, project, func(ctx context.Context) error {
+			name := "search"
+			_, err := c.UpdateProject(ctx, "org-1", "proj-1", client.ProjectUpdate{Name: &name})
+			return err
+		}},
+		{"delete project", 200, `{"success":true}`, func(ctx context.Context) error {
+			return c.DeleteProject(ctx, "org-1", "proj-1")
+		}},
+		{"list project API keys", 200, `{"apiKeys":[{"id":"key-1","createdAt":"2025-01-01T00:00:00.000Z","expiresAt":null,"lastUsedAt":null,"note":null,"publicKey":"pk-lf-1","displaySecretKey":"sk-lf-...abcd"}]}`, func(ctx context.Context) error {
+			_, err := c.ListProjectAPIKeys(ctx, "org-1", "proj-1")
+			return err
+		}},
+		{"create project API key", 201, `{"id":"key-2","createdAt":"2025-01-01T00:00:00.000Z","publicKey":"pk-lf-2","secretKey":"sk-lf-2","displaySecretKey":"sk-lf-...lf-2","note":"ci"}`, func(ctx context.Context) error {
+			_, err := c.CreateProjectAPIKey(ctx, "org-1", "proj-1", client.APIKeyCreate{Note: "ci"})
+			return err
+		}},
+		{"delete project API key", 200, `{"success":true}`, func(ctx context.Context) error {
+			return c.DeleteProjectAPIKey(ctx, "org-1", "proj-1", "key-2")
+		}},
+		{"get prompt", 200, prompt, func(ctx context.Context) error {
+			_, err := c.GetPrompt(ctx, "greeting", client.PromptSelector{Label: "production"})
+			return err
+		}},
+		{"set prompt labels", 200, prompt, func(ctx context.Context) error {
+			_, err := c.SetPromptLabels(ctx, "greeting", 2, []string{"production"})
+			return err
+		}},
+		{"get dataset run", 200, run, func(ctx context.Context) error {
+			_, err := c.GetDatasetRun(ctx, "qa", "nightly")
+			return err
+		}},
+		{"list annotation queue items", 200, `{"data":[{"id":"queue-item-1","queueId":"queue-1","objectId":"trace-1","objectType":"TRACE","status":"PENDING","completedAt":null,"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z"}],"meta":{"page":1,"limit":50,"totalItems":1,"totalPages":1}}`, func(ctx context.Context) error {
+			_, err := c.ListAnnotationQueueItems(ctx, "queue-1", client.AnnotationQueueStatusPending)
+			return err
+		}},
+		{"get media", 200, media, func(ctx context.Context) error {
+			_, err := c.GetMedia(ctx, "media-1")
+			return err
+		}},
+		{"upload media", 200, `{"mediaId":"media-1","uploadUrl":"` + ts.URL + `/upload/media-1"}`, func(ctx context.Context) error {
+			_, err := c.UploadMedia(ctx, client.MediaUpload{TraceID: "trace-1", ContentType: "image/png", Field: "input"}, bytes.NewReader([]byte("\x89PNG")))
+			return err
+		}},
+		{"server info", 200, `{"status":"OK","version":"3.0.0"}`, func(ctx context.Context) error {
+			_, err := c.ServerInfo(ctx)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.reset(tt.status, tt.body)
+			callErr := tt.call(context.Background())
+			problems := srv.reset(0, "")
+			for _, p := range problems {
+				if errors.Is(p, errNotInSpec) {
+					t.Skip(p)
+				}
+			}
+			for _, p := range problems {
+				t.Error(p)
+			}
+			if callErr != nil {
+				t.Errorf("client failed on a conforming response: %v", callErr)
+			}
+		})
+	}
+}
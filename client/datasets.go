@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetDatasetRun calls GET /api/public/datasets/{datasetName}/runs/{runName}
+// and returns the run along with its items. Requires public API credentials
+// of the project owning the dataset. The returned error matches ErrNotFound
+// when the dataset or the run does not exist.
+func (c *Client) GetDatasetRun(ctx context.Context, datasetName, runName string) (*DatasetRun, error) {
+	apiPath, err := escapePath("/api/public/datasets/%s/runs/%s", datasetName, runName)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, publicAPI, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("get dataset run", resp)
+	}
+	var run DatasetRun
+	if err := c.decodeJSON(ctx, "get dataset run", resp.Body, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
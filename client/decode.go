@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// UnknownFieldMode controls how response fields that the client does not know
+// about are handled. Such fields usually mean that the Langfuse instance is
+// newer than the provider.
+type UnknownFieldMode int
+
+const (
+	// UnknownFieldsIgnore drops unknown fields silently.
+	UnknownFieldsIgnore UnknownFieldMode = iota
+	// UnknownFieldsWarn logs a warning the first time an unknown field is seen
+	// in the response of an operation.
+	UnknownFieldsWarn
+	// UnknownFieldsFail fails the call with an *UnknownFieldsError.
+	UnknownFieldsFail
+)
+
+// WithUnknownFieldMode sets how unknown response fields are handled. The
+// default is UnknownFieldsIgnore.
+func WithUnknownFieldMode(m UnknownFieldMode) Option {
+	return func(c *Client) {
+		c.unknownFields = m
+	}
+}
+
+// UnknownFieldsError is returned in UnknownFieldsFail mode when a response
+// contains fields the client does not know about.
+type UnknownFieldsError struct {
+	Operation string
+	Fields    []string
+}
+
+// Error implements error.
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("%s: response contains unknown fields %s", e.Operation, strings.Join(e.Fields, ", "))
+}
+
+// decodeJSON reads the JSON document from r into v, checking for unknown
+// fields according to the client's UnknownFieldMode. Bodies larger than
+// maxResponseBodySize are rejected in every mode.
+func (c *Client) decodeJSON(ctx context.Context, operation string, r io.Reader, v any) error {
+	data, err := readBody(r, maxResponseBodySize)
+	if err != nil {
+		return err
+	}
+	return c.unmarshalJSON(ctx, operation, data, v)
+}
+
+// unmarshalJSON decodes data into v, checking for unknown fields according to
+// the client's UnknownFieldMode.
+func (c *Client) unmarshalJSON(ctx context.Context, operation string, data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	if c.unknownFields == UnknownFieldsIgnore {
+		return nil
+	}
+	fields := unknownFields(data, v)
+	if len(fields) == 0 {
+		return nil
+	}
+	if c.unknownFields == UnknownFieldsFail {
+		return &UnknownFieldsError{Operation: operation, Fields: fields}
+	}
+	for _, f := range fields {
+		if _, seen := c.warnedFields.LoadOrStore(operation+"\x00"+f, true); seen {
+			continue
+		}
+		tflog.Warn(ctx, "Langfuse API response contains a field unknown to the provider; it is ignored", map[string]interface{}{
+			"operation": operation,
+			"field":     f,
+		})
+	}
+	return nil
+}
+
+// unmarshaler returns unmarshalJSON bound to ctx and operation.
+func (c *Client) unmarshaler(ctx context.Context, operation string) func([]byte, any) error {
+	return func(data []byte, v any) error {
+		return c.unmarshalJSON(ctx, operation, data, v)
+	}
+}
+
+// unknownFields returns the top-level keys of the JSON object data that do not
+// correspond to a field of the struct v points to, sorted. Keys are matched
+// case-insensitively, as encoding/json does.
+func unknownFields(data []byte, v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil
+	}
+
+	known := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+
+	var unknown []string
+	for key := range obj {
+		if !known[strings.ToLower(key)] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
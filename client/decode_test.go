@@ -0,0 +1,122 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestUnknownFieldModes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/admin/organizations":
+			fmt.Fprint(w, `{"organizations":[{"id":"org-1","name":"team","region":"eu"}],"meta":{"page":1,"limit":50,"totalItems":1,"totalPages":1}}`)
+		default:
+			fmt.Fprint(w, `{"ID":"org-1","Name":"team","region":"eu","createdAt":"2025-01-01T00:00:00Z"}`)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name      string
+		mode      UnknownFieldMode
+		wantErr   bool
+		wantWarns int
+	}{
+		{name: "ignore", mode: UnknownFieldsIgnore},
+		{name: "warn", mode: UnknownFieldsWarn, wantWarns: 3},
+		{name: "fail", mode: UnknownFieldsFail, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &out)
+			c := NewClient(srv.URL, "admin-key", WithUnknownFieldMode(tt.mode))
+
+			// Reading twice warns about each field only once.
+			for range 2 {
+				org, err := c.GetOrganization(ctx, "org-1")
+				var unknown *UnknownFieldsError
+				if tt.wantErr {
+					if !errors.As(err, &unknown) || unknown.Operation != "get organization" || !slices.Equal(unknown.Fields, []string{"createdAt", "region"}) {
+						t.Fatalf("GetOrganization = %v, want unknown fields createdAt and region", err)
+					}
+					continue
+				}
+				// Known fields match case-insensitively, like encoding/json.
+				if err != nil || org.ID != "org-1" || org.Name != "team" {
+					t.Fatalf("GetOrganization = %+v, %v", org, err)
+				}
+			}
+
+			orgs, err := c.ListOrganizations(ctx)
+			if tt.wantErr {
+				if !errors.As(err, new(*UnknownFieldsError)) {
+					t.Fatalf("ListOrganizations = %v, want an *UnknownFieldsError", err)
+				}
+				return
+			}
+			if err != nil || len(orgs) != 1 {
+				t.Fatalf("ListOrganizations = %v, %v", orgs, err)
+			}
+			if got := strings.Count(out.String(), "field unknown to the provider"); got != tt.wantWarns {
+				t.Errorf("logged %d warnings, want %d:\n%s", got, tt.wantWarns, out.String())
+			}
+		})
+	}
+}
+
+func TestUnknownFields(t *testing.T) {
+	type item struct {
+		ID       string `json:"id"`
+		Name     string `json:"name,omitempty"`
+		Internal string `json:"-"`
+		Plain    string
+		hidden   string
+	}
+	tests := []struct {
+		data string
+		v    any
+		want []string
+	}{
+		{data: `{"id":"1","name":"a","Plain":"b"}`, v: &item{}},
+		{data: `{"ID":"1","NAME":"a","plain":"b"}`, v: &item{}},
+		{data: `{"id":"1","zeta":1,"alpha":2}`, v: &item{}, want: []string{"alpha", "zeta"}},
+		{data: `{"id":"1","Internal":"x","hidden":"y"}`, v: &item{}, want: []string{"Internal", "hidden"}},
+		{data: `{"id":"1","extra":true}`, v: &[]item{}},
+		{data: `["not an object"]`, v: &item{}},
+	}
+	for _, tt := range tests {
+		if got := unknownFields([]byte(tt.data), tt.v); !slices.Equal(got, tt.want) {
+			t.Errorf("unknownFields(%s) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeJSONBodyLimit(t *testing.T) {
+	// A valid document padded past the limit with trailing whitespace, which a
+	// streaming decoder would stop reading before.
+	body := `{"id":"org-1","name":"team"}` + strings.Repeat(" ", maxResponseBodySize)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	for _, mode := range []UnknownFieldMode{UnknownFieldsIgnore, UnknownFieldsWarn, UnknownFieldsFail} {
+		c := NewClient(srv.URL, "admin-key", WithUnknownFieldMode(mode))
+		_, err := c.GetOrganization(context.Background(), "org-1")
+		if err == nil || !strings.Contains(err.Error(), "response body exceeds") {
+			t.Errorf("mode %d: GetOrganization = %v, want the body limit error", mode, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnnotationQueueStatuses returns all known annotation queue item statuses.
+func AnnotationQueueStatuses() []AnnotationQueueStatus {
+	return []AnnotationQueueStatus{AnnotationQueueStatusPending, AnnotationQueueStatusCompleted}
+}
+
+// Valid reports whether s is a known annotation queue item status.
+func (s AnnotationQueueStatus) Valid() bool {
+	return isOneOf(s, AnnotationQueueStatuses())
+}
+
+// ParseAnnotationQueueStatus returns the annotation queue item status named s,
+// which must match exactly.
+func ParseAnnotationQueueStatus(s string) (AnnotationQueueStatus, error) {
+	st := AnnotationQueueStatus(s)
+	if !st.Valid() {
+		return "", fmt.Errorf("invalid annotation queue status %q, expected one of %s", s, joinEnum(AnnotationQueueStatuses()))
+	}
+	return st, nil
+}
+
+// EnumStrings converts enum values to plain strings, e.g. for schema validators.
+func EnumStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// isOneOf reports whether v is contained in values.
+func isOneOf[T comparable](v T, values []T) bool {
+	for _, candidate := range values {
+		if v == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// joinEnum formats values as a comma-separated list for error messages.
+func joinEnum[T ~string](values []T) string {
+	return strings.Join(EnumStrings(values), ", ")
+}
@@ -0,0 +1,22 @@
+package client
+
+import "testing"
+
+func TestParseAnnotationQueueStatus(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    AnnotationQueueStatus
+		wantErr bool
+	}{
+		{in: "PENDING", want: AnnotationQueueStatusPending},
+		{in: "COMPLETED", want: AnnotationQueueStatusCompleted},
+		{in: "pending", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAnnotationQueueStatus(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAnnotationQueueStatus(%q) = %q, %v; want %q, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
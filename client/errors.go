@@ -0,0 +1,165 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound matches, via errors.Is, any error reporting that the requested
+// object does not exist.
+var ErrNotFound = errors.New("not found")
+
+// APIError is returned when the Langfuse API answers with a non-success status.
+type APIError struct {
+	// Operation describes the client call, e.g. "create organization".
+	Operation string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// Message is the error message reported by Langfuse, or the raw response
+	// body when it could not be decoded.
+	Message string
+	// Code is the machine-readable error code reported by Langfuse, if any.
+	Code string
+	// Details lists additional problems reported by Langfuse, e.g. the
+	// individual validation failures of a 400 response.
+	Details []string
+	// RequestID is the request or correlation identifier returned by the
+	// server or a proxy in front of it, if any. See requestIDHeaders.
+	RequestID string
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s failed: %s %s returned %d %s", e.Operation, e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if len(e.Details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(e.Details, "; "))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches target. A 404 response matches ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// requestIDHeaders lists the response headers that may carry an identifier
+// for the request, in order of preference. Langfuse itself sets X-Request-Id;
+// the others are added by common reverse proxies and load balancers.
+var requestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Correlation-Id",
+	"X-Amzn-Requestid",
+	"X-Amzn-Trace-Id",
+	"Cf-Ray",
+	"X-Vercel-Id",
+}
+
+// requestID returns the first request identifier found in h.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := strings.TrimSpace(h.Get(name)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// errorBody is the JSON error payload returned by Langfuse.
+type errorBody struct {
+	Message string          `json:"message"`
+	Error   string          `json:"error"`
+	Code    string          `json:"code"`
+	Details json.RawMessage `json:"details"`
+}
+
+// errorDetail is one entry of a structured details list, such as a
+// validation issue.
+type errorDetail struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+	Field   string `json:"field"`
+}
+
+// parseDetails flattens the details of an error payload into messages. Lists
+// of strings and lists of objects with a message (and optional path or field)
+// are understood; anything else is ignored.
+func parseDetails(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil
+	}
+
+	var out []string
+	for _, item := range list {
+		var s string
+		if err := json.Unmarshal(item, &s); err == nil {
+			out = append(out, s)
+			continue
+		}
+		var d errorDetail
+		if err := json.Unmarshal(item, &d); err != nil || d.Message == "" {
+			continue
+		}
+		field := d.Field
+		if field == "" && len(d.Path) > 0 {
+			parts := make([]string, len(d.Path))
+			for i, p := range d.Path {
+				parts[i] = fmt.Sprint(p)
+			}
+			field = strings.Join(parts, ".")
+		}
+		if field != "" {
+			out = append(out, field+": "+d.Message)
+		} else {
+			out = append(out, d.Message)
+		}
+	}
+	return out
+}
+
+// newAPIError builds an APIError from a failed response, consuming its body.
+func newAPIError(operation string, resp *http.Response) *APIError {
+	apiErr := &APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		RequestID:  requestID(resp.Header),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.Path = resp.Request.URL.Path
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	// Closing the body frees its request slot, so callers may send follow-up
+	// requests, e.g. to explain the error, before their deferred close runs.
+	drainAndClose(resp.Body)
+	var body errorBody
+	if err := json.Unmarshal(b, &body); err == nil && (body.Message != "" || body.Error != "") {
+		apiErr.Message = body.Message
+		if apiErr.Message == "" {
+			apiErr.Message = body.Error
+		}
+		apiErr.Code = body.Code
+		apiErr.Details = parseDetails(body.Details)
+	} else {
+		apiErr.Message = strings.TrimSpace(string(b))
+	}
+	return apiErr
+}
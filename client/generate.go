@@ -0,0 +1,8 @@
+package client
+
+// The pinned OpenAPI document is refreshed first; the public API types are
+// then generated from it. The admin API is not part of the published document,
+// so its types (Organization, Project, APIKey and the request bodies) are
+// written by hand.
+//go:generate curl -fsSL -o testdata/openapi.yml https://cloud.langfuse.com/generated/api/openapi.yml
+//go:generate go run ./internal/typegen -spec testdata/openapi.yml -package client -out openapi_types.go Prompt DatasetRun=DatasetRunWithItems DatasetRunItem AnnotationQueueItem AnnotationQueueStatus AnnotationQueueObjectType Media=GetMediaResponse mediaUploadRequest=GetMediaUploadUrlRequest mediaUploadTicket=GetMediaUploadUrlResponse mediaUploadStatus=PatchMediaBody ServerInfo=HealthResponse
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Hook observes the HTTP requests sent by the client. Hooks are invoked for
+// every attempt, including retries, in the order they were registered.
+type Hook interface {
+	// OnRequest is called before a request is sent. The returned context is
+	// attached to the request and passed to OnResponse, which lets hooks carry
+	// per-request state such as trace spans. req is a copy of the request of
+	// the attempt, so hooks may add headers to it.
+	OnRequest(ctx context.Context, req *http.Request) context.Context
+	// OnResponse is called once the attempt finished. Exactly one of resp and
+	// err is non-nil. The response body must not be consumed.
+	OnResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+}
+
+// HookFuncs adapts plain functions to the Hook interface. Nil fields are skipped.
+type HookFuncs struct {
+	Request  func(ctx context.Context, req *http.Request) context.Context
+	Response func(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration)
+}
+
+// OnRequest implements Hook.
+func (h HookFuncs) OnRequest(ctx context.Context, req *http.Request) context.Context {
+	if h.Request == nil {
+		return ctx
+	}
+	return h.Request(ctx, req)
+}
+
+// OnResponse implements Hook.
+func (h HookFuncs) OnResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	if h.Response != nil {
+		h.Response(ctx, req, resp, err, elapsed)
+	}
+}
+
+// WithHooks registers hooks that observe every request sent by the client.
+func WithHooks(hooks ...Hook) Option {
+	return func(c *Client) {
+		c.hooks = append(c.hooks, hooks...)
+	}
+}
+
+// hookTransport is an http.RoundTripper that runs the registered hooks around
+// each request.
+type hookTransport struct {
+	next  http.RoundTripper
+	hooks []Hook
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Hooks may set headers, e.g. for trace propagation; a RoundTripper must
+	// not modify the request it was given.
+	ctx := req.Context()
+	req = req.Clone(ctx)
+	for _, h := range t.hooks {
+		ctx = h.OnRequest(ctx, req)
+	}
+	req = req.WithContext(ctx)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start)
+	for _, h := range t.hooks {
+		h.OnResponse(ctx, req, resp, err, elapsed)
+	}
+	return resp, err
+}
@@ -0,0 +1,292 @@
+// Command typegen generates Go types for schemas of an OpenAPI document.
+//
+// Usage:
+//
+//	typegen -spec openapi.yml -package client -out types.go Schema GoName=Schema ...
+//
+// Each argument names a component schema, optionally preceded by the name of
+// the generated type. Objects become structs with a field per property;
+// properties that are not required are omitted when empty. allOf is
+// flattened, and the variants of a oneOf are merged into one struct, with
+// properties whose types differ between variants kept as raw JSON. String
+// enums become a named string type with a constant per value. Schemas without
+// a type are kept as raw JSON, and referenced objects must be generated too.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func main() {
+	spec := flag.String("spec", "", "OpenAPI document to read")
+	pkg := flag.String("package", "", "package of the generated file")
+	out := flag.String("out", "", "file to write")
+	flag.Parse()
+	if *spec == "" || *pkg == "" || *out == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	src, err := generate(*spec, *pkg, flag.Args())
+	if err == nil {
+		err = os.WriteFile(*out, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "typegen:", err)
+		os.Exit(1)
+	}
+}
+
+// rawJSON is the Go type of values kept as undecoded JSON.
+const rawJSON = "json.RawMessage"
+
+// generator writes Go types for the schemas of an OpenAPI document.
+type generator struct {
+	schemas openapi3.Schemas
+	// names maps the names of generated schemas to their Go type names.
+	names map[string]string
+	// usesJSON records whether the output refers to encoding/json.
+	usesJSON bool
+}
+
+// field is a property of a generated struct.
+type field struct {
+	goType      string
+	required    bool
+	description string
+}
+
+// generate returns the formatted source declaring the requested types.
+func generate(specPath, pkg string, types []string) ([]byte, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(specPath)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{schemas: doc.Components.Schemas, names: map[string]string{}}
+	var order []string
+	for _, t := range types {
+		goName, schemaName, ok := strings.Cut(t, "=")
+		if !ok {
+			schemaName = goName
+		}
+		if g.schemas[schemaName] == nil {
+			return nil, fmt.Errorf("schema %s is not defined", schemaName)
+		}
+		g.names[schemaName] = goName
+		order = append(order, schemaName)
+	}
+
+	var body bytes.Buffer
+	for _, name := range order {
+		if err := g.writeType(&body, name); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by typegen from %s; DO NOT EDIT.\n\npackage %s\n\n", filepath.Base(specPath), pkg)
+	if g.usesJSON {
+		src.WriteString("import \"encoding/json\"\n\n")
+	}
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// writeType writes the declaration of the named schema.
+func (g *generator) writeType(w *bytes.Buffer, name string) error {
+	s := g.schemas[name].Value
+	goName := g.names[name]
+	fmt.Fprintf(w, "// %s is generated from the %s schema.\n", goName, name)
+	writeComment(w, "", s.Description)
+
+	if s.Type.Is("string") && len(s.Enum) > 0 {
+		fmt.Fprintf(w, "type %s string\n\n", goName)
+		fmt.Fprintf(w, "// Values of %s.\nconst (\n", goName)
+		for _, v := range s.Enum {
+			value, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("enum value %v is not a string", v)
+			}
+			fmt.Fprintf(w, "\t%s%s %s = %q\n", goName, exportedName(strings.ToLower(value)), goName, value)
+		}
+		w.WriteString(")\n\n")
+		return nil
+	}
+
+	fields, err := g.fields(s)
+	if err != nil {
+		return err
+	}
+	jsonNames := make([]string, 0, len(fields))
+	for jsonName := range fields {
+		jsonNames = append(jsonNames, jsonName)
+	}
+	slices.Sort(jsonNames)
+	fmt.Fprintf(w, "type %s struct {\n", goName)
+	for _, jsonName := range jsonNames {
+		f := fields[jsonName]
+		if strings.HasPrefix(f.goType, "?") {
+			return fmt.Errorf("property %s refers to schema %s, which is not generated", jsonName, f.goType[1:])
+		}
+		writeComment(w, "\t", f.description)
+		tag := jsonName
+		if !f.required {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `json:%q`\n", exportedName(jsonName), f.goType, tag)
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// fields returns the properties of an object schema by JSON name.
+func (g *generator) fields(s *openapi3.Schema) (map[string]field, error) {
+	fields := map[string]field{}
+	switch {
+	case len(s.AllOf) > 0:
+		for _, part := range s.AllOf {
+			partFields, err := g.fields(part.Value)
+			if err != nil {
+				return nil, err
+			}
+			for name, f := range partFields {
+				if prev, ok := fields[name]; ok {
+					f.required = f.required || prev.required
+				}
+				fields[name] = f
+			}
+		}
+	case len(s.OneOf) > 0:
+		for i, variant := range s.OneOf {
+			variantFields, err := g.fields(variant.Value)
+			if err != nil {
+				return nil, err
+			}
+			for name, f := range fields {
+				if _, ok := variantFields[name]; !ok {
+					f.required = false
+					fields[name] = f
+				}
+			}
+			for name, f := range variantFields {
+				prev, ok := fields[name]
+				switch {
+				case !ok:
+					f.required = f.required && i == 0
+				case prev.goType != f.goType:
+					g.usesJSON = true
+					f.goType = rawJSON
+					f.required = f.required && prev.required
+				default:
+					f.required = f.required && prev.required
+				}
+				fields[name] = f
+			}
+		}
+	default:
+		for name, prop := range s.Properties {
+			goType, err := g.goType(prop)
+			if err != nil {
+				return nil, fmt.Errorf("property %s: %w", name, err)
+			}
+			fields[name] = field{
+				goType:      goType,
+				required:    slices.Contains(s.Required, name),
+				description: prop.Value.Description,
+			}
+		}
+	}
+	return fields, nil
+}
+
+// goType returns the Go type of values of ref. References to objects that are
+// not generated yield "?" followed by the schema name, so that oneOf variants
+// can still be compared; writeType rejects them.
+func (g *generator) goType(ref *openapi3.SchemaRef) (string, error) {
+	if ref.Ref != "" {
+		name := ref.Ref[strings.LastIndex(ref.Ref, "/")+1:]
+		if goName, ok := g.names[name]; ok {
+			return goName, nil
+		}
+		if t := ref.Value.Type; t.Is("object") || (t == nil && len(ref.Value.Properties) > 0) {
+			return "?" + name, nil
+		}
+	}
+	s := ref.Value
+	switch {
+	case s.Type.Is("string"):
+		return "string", nil
+	case s.Type.Is("integer"):
+		return "int", nil
+	case s.Type.Is("number"):
+		return "float64", nil
+	case s.Type.Is("boolean"):
+		return "bool", nil
+	case s.Type.Is("array"):
+		elem, err := g.goType(s.Items)
+		if strings.HasPrefix(elem, "?") {
+			return elem, err
+		}
+		return "[]" + elem, err
+	case len(s.Properties) > 0:
+		return "", fmt.Errorf("inline objects are not supported")
+	case s.AdditionalProperties.Schema != nil:
+		elem, err := g.goType(s.AdditionalProperties.Schema)
+		if strings.HasPrefix(elem, "?") {
+			return elem, err
+		}
+		return "map[string]" + elem, err
+	}
+	g.usesJSON = true
+	return rawJSON, nil
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{"api": true, "http": true, "id": true, "json": true, "sha256": true, "uri": true, "url": true}
+
+// exportedName turns a camelCase or snake_case name into an exported Go name.
+func exportedName(name string) string {
+	var words []string
+	start := 0
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '-':
+			words = append(words, name[start:i])
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+	words = append(words, name[start:])
+
+	var b strings.Builder
+	for _, w := range words {
+		if w == "" {
+			continue
+		}
+		if lower := strings.ToLower(w); initialisms[lower] {
+			b.WriteString(strings.ToUpper(lower))
+		} else {
+			b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+		}
+	}
+	return b.String()
+}
+
+// writeComment writes text as a Go comment, one line per line of text.
+func writeComment(w *bytes.Buffer, indent, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(w, "%s// %s\n", indent, line)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "name", want: "Name"},
+		{name: "datasetRunItems", want: "DatasetRunItems"},
+		{name: "datasetId", want: "DatasetID"},
+		{name: "urlExpiry", want: "URLExpiry"},
+		{name: "uploadHttpStatus", want: "UploadHTTPStatus"},
+		{name: "sha256Hash", want: "SHA256Hash"},
+		{name: "uploadTimeMs", want: "UploadTimeMs"},
+		{name: "in_progress", want: "InProgress"},
+	}
+	for _, tt := range tests {
+		if got := exportedName(tt.name); got != tt.want {
+			t.Errorf("exportedName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestGeneratedTypesUpToDate regenerates the client types with the arguments
+// of their go:generate directive and compares them with the checked-in file.
+func TestGeneratedTypesUpToDate(t *testing.T) {
+	directives, err := os.ReadFile("../../generate.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var args []string
+	for _, line := range strings.Split(string(directives), "\n") {
+		if rest, ok := strings.CutPrefix(line, "//go:generate go run ./internal/typegen "); ok {
+			args = strings.Fields(rest)
+		}
+	}
+	if len(args) < 6 || args[0] != "-spec" || args[2] != "-package" || args[4] != "-out" {
+		t.Fatalf("unexpected typegen directive arguments %q", args)
+	}
+
+	got, err := generate("../../"+args[1], args[3], args[6:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile("../../" + args[5])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run go generate ./client", args[5])
+	}
+}
+
+func TestGenerateRejectsUngeneratedReferences(t *testing.T) {
+	spec := t.TempDir() + "/openapi.yml"
+	os.WriteFile(spec, []byte(`openapi: 3.0.1
+info: {title: test, version: "1"}
+paths: {}
+components:
+  schemas:
+    Run:
+      type: object
+      properties:
+        owner: {$ref: '#/components/schemas/User'}
+    User:
+      type: object
+      properties:
+        id: {type: string}
+`), 0o644)
+	if _, err := generate(spec, "client", []string{"Run"}); err == nil || !strings.Contains(err.Error(), "refers to schema User, which is not generated") {
+		t.Errorf("generate = %v, want an error about User", err)
+	}
+	if _, err := generate(spec, "client", []string{"Run", "User"}); err != nil {
+		t.Errorf("generate with User: %v", err)
+	}
+}
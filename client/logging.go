@@ -0,0 +1,159 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "***"
+
+var (
+	// secretFieldPattern matches JSON string fields whose names indicate a credential.
+	secretFieldPattern = regexp.MustCompile(`(?i)("(?:secret_?key|api_?key|admin_?(?:api_?)?key|password|token|authorization)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// secretValuePattern matches Langfuse secret keys and bearer or basic
+	// credentials wherever they appear.
+	secretValuePattern = regexp.MustCompile(`sk-lf-[A-Za-z0-9-]+|(?i)bearer\s+[^\s"',]+|(?i)basic\s+[A-Za-z0-9+/]{12,}={0,2}`)
+)
+
+// RedactSecrets masks credentials in s, such as secret keys echoed back by the
+// API, so it can be logged or shown in diagnostics safely.
+func RedactSecrets(s string) string {
+	return redactSecrets(s)
+}
+
+// redactSecrets masks credentials in s so it can be logged safely.
+func redactSecrets(s string) string {
+	s = secretFieldPattern.ReplaceAllString(s, `$1"`+redacted+`"`)
+	return secretValuePattern.ReplaceAllString(s, redacted)
+}
+
+// redactHeaders returns a loggable copy of h with sensitive values masked.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		switch strings.ToLower(k) {
+		case "authorization", "cookie", "set-cookie":
+			out[k] = redacted
+		default:
+			out[k] = strings.Join(v, ", ")
+		}
+	}
+	return out
+}
+
+// httpSubsystem is the tflog subsystem request and response bodies are
+// logged to.
+const httpSubsystem = "http"
+
+// BodyLogEnvVar sets the log level of the HTTP subsystem. Request and response
+// bodies are only read, redacted and logged when it is TRACE; otherwise
+// responses are passed to the caller untouched.
+const BodyLogEnvVar = "TF_LOG_PROVIDER_LANGFUSE_HTTP"
+
+// bodyLoggingEnabled reports whether BodyLogEnvVar enables body logging.
+func bodyLoggingEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv(BodyLogEnvVar)), "trace")
+}
+
+// loggingTransport is an http.RoundTripper that writes every request and
+// response passing through it to the Terraform log. Bodies are only logged
+// when logBodies is set.
+type loggingTransport struct {
+	next      http.RoundTripper
+	logBodies bool
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.logBodies {
+		ctx = tflog.NewSubsystem(ctx, httpSubsystem, tflog.WithLevelFromEnv(BodyLogEnvVar))
+	}
+	logRequest(ctx, req, t.logBodies)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		tflog.Debug(ctx, "Langfuse API request failed", map[string]interface{}{
+			"method":      req.Method,
+			"url":         req.URL.String(),
+			"duration_ms": time.Since(start).Milliseconds(),
+			"error":       redactSecrets(err.Error()),
+		})
+		return nil, err
+	}
+	if err := logResponse(ctx, req, resp, time.Since(start), t.logBodies); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// logRequest writes the outgoing request to the Terraform log, along with its
+// body if logBodies is set.
+func logRequest(ctx context.Context, req *http.Request, logBodies bool) {
+	tflog.Trace(ctx, "Sending Langfuse API request", map[string]interface{}{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": redactHeaders(req.Header),
+	})
+	// Only JSON bodies are logged; uploads may be large binary content.
+	if !logBodies || req.GetBody == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return
+	}
+	if body, err := req.GetBody(); err == nil {
+		b, _ := io.ReadAll(body)
+		body.Close()
+		tflog.SubsystemTrace(ctx, httpSubsystem, "Langfuse API request body", map[string]interface{}{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"body":   redactSecrets(string(b)),
+		})
+	}
+}
+
+// logResponse writes the response summary to the Terraform log. With
+// logBodies set, the body is buffered and logged as well, and resp.Body is
+// replaced so the caller can still consume it; otherwise resp.Body is left
+// alone.
+func logResponse(ctx context.Context, req *http.Request, resp *http.Response, elapsed time.Duration, logBodies bool) error {
+	fields := map[string]interface{}{
+		"method":      req.Method,
+		"url":         req.URL.String(),
+		"status":      resp.StatusCode,
+		"duration_ms": elapsed.Milliseconds(),
+	}
+	if id := requestID(resp.Header); id != "" {
+		fields["request_id"] = id
+	}
+	tflog.Debug(ctx, "Langfuse API request completed", fields)
+	tflog.Trace(ctx, "Received Langfuse API response", map[string]interface{}{
+		"status":  resp.StatusCode,
+		"headers": redactHeaders(resp.Header),
+	})
+
+	// Streamed responses are decoded while they arrive; buffering them here
+	// would defeat the purpose.
+	if !logBodies || (isStreaming(ctx) && resp.StatusCode < 300) {
+		return nil
+	}
+
+	b, err := readBody(resp.Body, maxResponseBodySize)
+	drainAndClose(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response of %s %s: %w", req.Method, req.URL.Path, err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(b))
+	tflog.SubsystemTrace(ctx, httpSubsystem, "Langfuse API response body", map[string]interface{}{
+		"status": resp.StatusCode,
+		"body":   redactSecrets(string(b)),
+	})
+	return nil
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// MediaUpload describes a file to attach to a trace or observation through
+// the public media API.
+type MediaUpload struct {
+	// TraceID is the trace the media belongs to.
+	TraceID string
+	// ObservationID optionally narrows the attachment to one observation.
+	ObservationID string
+	// ContentType is the MIME type of the file, e.g. "image/png".
+	ContentType string
+	// Field is the trace or observation field referencing the media:
+	// "input", "output" or "metadata".
+	Field string
+}
+
+// UploadMedia uploads the contents of body and returns the ID of the media
+// object. It requests a presigned upload URL, PUTs the content to the storage
+// backend and reports the outcome back to Langfuse. The content is hashed
+// first, so body is read twice; content Langfuse already stores is not sent
+// again. Requires public API credentials.
+func (c *Client) UploadMedia(ctx context.Context, upload MediaUpload, body io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	size, err := io.Copy(hash, body)
+	if err != nil {
+		return "", fmt.Errorf("hashing media: %w", err)
+	}
+	checksum := base64.StdEncoding.EncodeToString(hash.Sum(nil))
+
+	ticket, err := c.requestMediaUpload(ctx, mediaUploadRequest{
+		TraceID:       upload.TraceID,
+		ObservationID: upload.ObservationID,
+		ContentType:   upload.ContentType,
+		ContentLength: int(size),
+		SHA256Hash:    checksum,
+		Field:         upload.Field,
+	})
+	if err != nil {
+		return "", err
+	}
+	// Without an upload URL, Langfuse already stores content with this hash.
+	if ticket.UploadURL == "" {
+		return ticket.MediaID, nil
+	}
+
+	start := time.Now()
+	status, uploadErr := c.putMedia(ctx, ticket.UploadURL, upload.ContentType, checksum, size, body)
+	report := mediaUploadStatus{
+		UploadedAt:       time.Now().UTC().Format(time.RFC3339Nano),
+		UploadHTTPStatus: status,
+		UploadTimeMs:     int(time.Since(start).Milliseconds()),
+	}
+	if uploadErr != nil {
+		report.UploadHTTPError = uploadErr.Error()
+	}
+	if err := c.reportMediaUpload(ctx, ticket.MediaID, report); err != nil {
+		if uploadErr != nil {
+			return "", uploadErr
+		}
+		return "", err
+	}
+	if uploadErr != nil {
+		return "", uploadErr
+	}
+	return ticket.MediaID, nil
+}
+
+// requestMediaUpload calls POST /api/public/media. The response is closed
+// before the upload starts, so it does not hold a request slot meanwhile.
+func (c *Client) requestMediaUpload(ctx context.Context, upload mediaUploadRequest) (*mediaUploadTicket, error) {
+	req, err := c.newRequest(ctx, publicAPI, http.MethodPost, "/api/public/media", upload)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("request media upload", resp)
+	}
+	var ticket mediaUploadTicket
+	if err := c.decodeJSON(ctx, "request media upload", resp.Body, &ticket); err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// putMedia sends the content to a presigned upload URL and returns the HTTP
+// status of the storage backend. The URL carries its own authorization, so
+// neither Langfuse credentials nor the Host override are sent. The request
+// bypasses the logging, metrics and hooks of Langfuse requests, which would
+// record the signature.
+func (c *Client) putMedia(ctx context.Context, uploadURL, contentType, checksum string, size int64, body io.ReadSeeker) (int, error) {
+	rewind := func() (io.ReadCloser, error) {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(body), nil
+	}
+	content, err := rewind()
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, content)
+	if err != nil {
+		return 0, err
+	}
+	req.ContentLength = size
+	req.GetBody = rewind
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Checksum-Sha256", checksum)
+
+	resp, err := c.uploadClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("uploading media: %w", err)
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		msg, _ := readBody(resp.Body, maxErrorBodySize)
+		return resp.StatusCode, fmt.Errorf("uploading media: storage returned %d %s: %s", resp.StatusCode, http.StatusText(resp.StatusCode), msg)
+	}
+	return resp.StatusCode, nil
+}
+
+// reportMediaUpload calls PATCH /api/public/media/{mediaId}.
+func (c *Client) reportMediaUpload(ctx context.Context, mediaID string, status mediaUploadStatus) error {
+	apiPath, err := escapePath("/api/public/media/%s", mediaID)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, publicAPI, http.MethodPatch, apiPath, status)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return newAPIError("report media upload", resp)
+	}
+	return nil
+}
+
+// GetMedia calls GET /api/public/media/{mediaId}. The returned URL is a
+// presigned download URL, valid until URLExpiry. Requires public API
+// credentials.
+func (c *Client) GetMedia(ctx context.Context, mediaID string) (*Media, error) {
+	apiPath, err := escapePath("/api/public/media/%s", mediaID)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, publicAPI, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("get media", resp)
+	}
+	var m Media
+	if err := c.decodeJSON(ctx, "get media", resp.Body, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
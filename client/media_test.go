@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestUploadMediaKeepsPresignedURLPrivate(t *testing.T) {
+	const signature = "X-Amz-Signature=0123456789abcdef"
+	var uploaded []byte
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/public/media":
+			json.NewEncoder(w).Encode(map[string]string{
+				"mediaId":   "media-1",
+				"uploadUrl": ts.URL + "/bucket/media-1?X-Amz-Credential=key&" + signature,
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/bucket/media-1":
+			var buf bytes.Buffer
+			buf.ReadFrom(r.Body)
+			uploaded = buf.Bytes()
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/public/media/media-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	var hooked []string
+	c := NewClient(ts.URL, "admin-key", WithPublicAPICredentials("pk-lf-1", "sk-lf-1"), WithHooks(HookFuncs{
+		Response: func(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+			hooked = append(hooked, req.URL.String())
+		},
+	}))
+	id, err := c.UploadMedia(ctx, MediaUpload{TraceID: "trace-1", ContentType: "image/png", Field: "input"}, bytes.NewReader([]byte("\x89PNG")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != "media-1" || string(uploaded) != "\x89PNG" {
+		t.Fatalf("got media %q with content %q", id, uploaded)
+	}
+
+	if strings.Contains(out.String(), signature) || strings.Contains(out.String(), "/bucket/") {
+		t.Errorf("presigned URL was logged: %s", out.String())
+	}
+	for _, u := range hooked {
+		if strings.Contains(u, "/bucket/") {
+			t.Errorf("hooks saw the presigned URL %s", u)
+		}
+	}
+	for endpoint := range c.Metrics() {
+		if strings.Contains(endpoint, "bucket") {
+			t.Errorf("metrics recorded the upload as %s", endpoint)
+		}
+	}
+	if len(c.Metrics()) != 2 {
+		t.Errorf("metrics recorded %v, want the two Langfuse requests", c.Metrics())
+	}
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLatencySamples bounds the latencies kept per endpoint for the percentile
+// estimate; older samples are overwritten once it is reached.
+const maxLatencySamples = 1024
+
+// EndpointStats summarizes the requests sent to one endpoint.
+type EndpointStats struct {
+	// Requests counts every attempt, including retries.
+	Requests int
+	// Retries counts the attempts after the first one.
+	Retries int
+	// ClientErrors and ServerErrors count 4xx and 5xx responses.
+	ClientErrors int
+	ServerErrors int
+	// TransportErrors counts attempts that got no response at all.
+	TransportErrors int
+	// P95Latency is the 95th percentile of the recent attempt latencies.
+	P95Latency time.Duration
+}
+
+// endpointMetrics accumulates the statistics of one endpoint.
+type endpointMetrics struct {
+	stats     EndpointStats
+	latencies []time.Duration
+	next      int
+}
+
+// metrics collects per-endpoint request statistics. Endpoints are keyed by
+// method and route template, so requests for different IDs share an entry.
+type metrics struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+	// logged holds the counters as of the previous delta call.
+	logged map[string]EndpointStats
+}
+
+// record adds one attempt to the statistics.
+func (m *metrics) record(req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	key := req.Method + " " + routeTemplate(req.URL.EscapedPath())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.endpoints == nil {
+		m.endpoints = map[string]*endpointMetrics{}
+	}
+	e, ok := m.endpoints[key]
+	if !ok {
+		e = &endpointMetrics{}
+		m.endpoints[key] = e
+	}
+
+	e.stats.Requests++
+	if attemptNumber(req.Context()) > 0 {
+		e.stats.Retries++
+	}
+	switch {
+	case err != nil:
+		e.stats.TransportErrors++
+	case resp.StatusCode >= 500:
+		e.stats.ServerErrors++
+	case resp.StatusCode >= 400:
+		e.stats.ClientErrors++
+	}
+	if len(e.latencies) < maxLatencySamples {
+		e.latencies = append(e.latencies, elapsed)
+	} else {
+		e.latencies[e.next] = elapsed
+		e.next = (e.next + 1) % maxLatencySamples
+	}
+}
+
+// snapshot returns the current statistics keyed by endpoint.
+func (m *metrics) snapshot() map[string]EndpointStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]EndpointStats, len(m.endpoints))
+	for key, e := range m.endpoints {
+		s := e.stats
+		s.P95Latency = percentile(e.latencies, 0.95)
+		out[key] = s
+	}
+	return out
+}
+
+// delta returns the statistics of the endpoints that received requests since
+// the previous call, with counters covering only those requests.
+// P95Latency still covers all recent attempts.
+func (m *metrics) delta() map[string]EndpointStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.logged == nil {
+		m.logged = map[string]EndpointStats{}
+	}
+	out := map[string]EndpointStats{}
+	for key, e := range m.endpoints {
+		prev := m.logged[key]
+		if e.stats.Requests == prev.Requests {
+			continue
+		}
+		out[key] = EndpointStats{
+			Requests:        e.stats.Requests - prev.Requests,
+			Retries:         e.stats.Retries - prev.Retries,
+			ClientErrors:    e.stats.ClientErrors - prev.ClientErrors,
+			ServerErrors:    e.stats.ServerErrors - prev.ServerErrors,
+			TransportErrors: e.stats.TransportErrors - prev.TransportErrors,
+			P95Latency:      percentile(e.latencies, 0.95),
+		}
+		m.logged[key] = e.stats
+	}
+	return out
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) of samples using the
+// nearest-rank method.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	rank = max(0, min(rank, len(sorted)-1))
+	return sorted[rank]
+}
+
+// metricsTransport is an http.RoundTripper that records every attempt passing
+// through it.
+type metricsTransport struct {
+	next    http.RoundTripper
+	metrics *metrics
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.metrics.record(req, resp, err, time.Since(start))
+	return resp, err
+}
+
+// Metrics returns the request statistics collected since the client was
+// created, keyed by "METHOD /route/template".
+func (c *Client) Metrics() map[string]EndpointStats {
+	return c.metrics.snapshot()
+}
+
+// LogMetrics writes the requests sent since the previous call to the
+// Terraform log, one DEBUG entry per endpoint that received any, so repeated
+// calls do not log the same requests again. The entries of a run add up to
+// the totals returned by Metrics.
+func (c *Client) LogMetrics(ctx context.Context) {
+	stats := c.metrics.delta()
+	keys := make([]string, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := stats[key]
+		tflog.Debug(ctx, "Langfuse API client metrics", map[string]interface{}{
+			"endpoint":         key,
+			"requests":         s.Requests,
+			"retries":          s.Retries,
+			"client_errors":    s.ClientErrors,
+			"server_errors":    s.ServerErrors,
+			"transport_errors": s.TransportErrors,
+			"p95_latency_ms":   s.P95Latency.Milliseconds(),
+		})
+	}
+}
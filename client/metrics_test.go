@@ -0,0 +1,54 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestMetricsDelta(t *testing.T) {
+	var m metrics
+	get, _ := http.NewRequest(http.MethodGet, "https://langfuse.example.com/api/admin/organizations/org-1", nil)
+	post, _ := http.NewRequest(http.MethodPost, "https://langfuse.example.com/api/admin/organizations", nil)
+	ok := &http.Response{StatusCode: http.StatusOK}
+
+	m.record(get, ok, nil, time.Millisecond)
+	m.record(get, &http.Response{StatusCode: http.StatusNotFound}, nil, time.Millisecond)
+	first := m.delta()
+	if s := first["GET /api/admin/organizations/{orgId}"]; s.Requests != 2 || s.ClientErrors != 1 {
+		t.Errorf("first delta = %+v, want 2 requests with 1 client error", first)
+	}
+
+	// Only the requests since the previous call are reported.
+	m.record(post, nil, errors.New("connection reset"), time.Millisecond)
+	second := m.delta()
+	if len(second) != 1 || second["POST /api/admin/organizations"].TransportErrors != 1 {
+		t.Errorf("second delta = %+v, want only the POST with 1 transport error", second)
+	}
+	if third := m.delta(); len(third) != 0 {
+		t.Errorf("delta without new requests = %+v, want none", third)
+	}
+	if total := m.snapshot()["GET /api/admin/organizations/{orgId}"].Requests; total != 2 {
+		t.Errorf("snapshot counts %d GET requests, want the cumulative 2", total)
+	}
+}
+
+func TestLogMetricsLogsEachRequestOnce(t *testing.T) {
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &out)
+	c := NewClient("https://langfuse.example.com", "admin-key")
+	req, _ := http.NewRequest(http.MethodGet, "https://langfuse.example.com/api/admin/organizations", nil)
+	c.metrics.record(req, &http.Response{StatusCode: http.StatusOK}, nil, time.Millisecond)
+
+	c.LogMetrics(ctx)
+	c.LogMetrics(ctx)
+	if n := strings.Count(out.String(), "Langfuse API client metrics"); n != 1 {
+		t.Errorf("logged %d metrics entries, want 1", n)
+	}
+}
@@ -0,0 +1,115 @@
+// Code generated by typegen from openapi.yml; DO NOT EDIT.
+
+package client
+
+import "encoding/json"
+
+// Prompt is generated from the Prompt schema.
+type Prompt struct {
+	CommitMessage   string                     `json:"commitMessage,omitempty"`
+	Config          json.RawMessage            `json:"config"`
+	Labels          []string                   `json:"labels"`
+	Name            string                     `json:"name"`
+	Prompt          json.RawMessage            `json:"prompt"`
+	ResolutionGraph map[string]json.RawMessage `json:"resolutionGraph,omitempty"`
+	Tags            []string                   `json:"tags"`
+	Type            string                     `json:"type"`
+	Version         int                        `json:"version"`
+}
+
+// DatasetRun is generated from the DatasetRunWithItems schema.
+type DatasetRun struct {
+	CreatedAt       string           `json:"createdAt"`
+	DatasetID       string           `json:"datasetId"`
+	DatasetName     string           `json:"datasetName"`
+	DatasetRunItems []DatasetRunItem `json:"datasetRunItems"`
+	Description     string           `json:"description,omitempty"`
+	ID              string           `json:"id"`
+	Metadata        json.RawMessage  `json:"metadata,omitempty"`
+	Name            string           `json:"name"`
+	UpdatedAt       string           `json:"updatedAt"`
+}
+
+// DatasetRunItem is generated from the DatasetRunItem schema.
+type DatasetRunItem struct {
+	CreatedAt      string `json:"createdAt"`
+	DatasetItemID  string `json:"datasetItemId"`
+	DatasetRunID   string `json:"datasetRunId"`
+	DatasetRunName string `json:"datasetRunName"`
+	ID             string `json:"id"`
+	ObservationID  string `json:"observationId,omitempty"`
+	TraceID        string `json:"traceId"`
+	UpdatedAt      string `json:"updatedAt"`
+}
+
+// AnnotationQueueItem is generated from the AnnotationQueueItem schema.
+type AnnotationQueueItem struct {
+	CompletedAt string                    `json:"completedAt,omitempty"`
+	CreatedAt   string                    `json:"createdAt"`
+	ID          string                    `json:"id"`
+	ObjectID    string                    `json:"objectId"`
+	ObjectType  AnnotationQueueObjectType `json:"objectType"`
+	QueueID     string                    `json:"queueId"`
+	Status      AnnotationQueueStatus     `json:"status"`
+	UpdatedAt   string                    `json:"updatedAt"`
+}
+
+// AnnotationQueueStatus is generated from the AnnotationQueueStatus schema.
+type AnnotationQueueStatus string
+
+// Values of AnnotationQueueStatus.
+const (
+	AnnotationQueueStatusPending   AnnotationQueueStatus = "PENDING"
+	AnnotationQueueStatusCompleted AnnotationQueueStatus = "COMPLETED"
+)
+
+// AnnotationQueueObjectType is generated from the AnnotationQueueObjectType schema.
+type AnnotationQueueObjectType string
+
+// Values of AnnotationQueueObjectType.
+const (
+	AnnotationQueueObjectTypeTrace       AnnotationQueueObjectType = "TRACE"
+	AnnotationQueueObjectTypeObservation AnnotationQueueObjectType = "OBSERVATION"
+	AnnotationQueueObjectTypeSession     AnnotationQueueObjectType = "SESSION"
+)
+
+// Media is generated from the GetMediaResponse schema.
+type Media struct {
+	ContentLength int    `json:"contentLength"`
+	ContentType   string `json:"contentType"`
+	MediaID       string `json:"mediaId"`
+	UploadedAt    string `json:"uploadedAt"`
+	URL           string `json:"url"`
+	URLExpiry     string `json:"urlExpiry"`
+}
+
+// mediaUploadRequest is generated from the GetMediaUploadUrlRequest schema.
+type mediaUploadRequest struct {
+	ContentLength int    `json:"contentLength"`
+	ContentType   string `json:"contentType"`
+	Field         string `json:"field"`
+	ObservationID string `json:"observationId,omitempty"`
+	SHA256Hash    string `json:"sha256Hash"`
+	TraceID       string `json:"traceId"`
+}
+
+// mediaUploadTicket is generated from the GetMediaUploadUrlResponse schema.
+type mediaUploadTicket struct {
+	MediaID   string `json:"mediaId"`
+	UploadURL string `json:"uploadUrl,omitempty"`
+}
+
+// mediaUploadStatus is generated from the PatchMediaBody schema.
+type mediaUploadStatus struct {
+	UploadHTTPError  string `json:"uploadHttpError,omitempty"`
+	UploadHTTPStatus int    `json:"uploadHttpStatus"`
+	UploadTimeMs     int    `json:"uploadTimeMs,omitempty"`
+	UploadedAt       string `json:"uploadedAt"`
+}
+
+// ServerInfo is generated from the HealthResponse schema.
+type ServerInfo struct {
+	Status string `json:"status"`
+	// Langfuse server version
+	Version string `json:"version"`
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// maxPagesWithoutMeta bounds lists whose endpoint reports no pagination
+// metadata. Such lists end with a short page, so a server ignoring the page
+// parameter would otherwise be polled forever.
+const maxPagesWithoutMeta = 10000
+
+// PageMeta is the pagination metadata returned by Langfuse list endpoints.
+type PageMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	TotalItems int `json:"totalItems"`
+	TotalPages int `json:"totalPages"`
+}
+
+// Page is a single page of results from a list endpoint. Meta is nil when the
+// endpoint does not report pagination metadata.
+type Page[T any] struct {
+	Items []T
+	Meta  *PageMeta
+}
+
+// PageFetcher retrieves the given 1-based page holding up to limit items.
+type PageFetcher[T any] func(ctx context.Context, page, limit int) (Page[T], error)
+
+// All iterates over every item of a paginated list, requesting pages of
+// pageSize items on demand. Iteration stops after the last page or at the
+// first error, which is yielded with a zero item. Without metadata, a page
+// starting with the same item as the one before it, or more than
+// maxPagesWithoutMeta pages, is an error.
+func All[T any](ctx context.Context, pageSize int, fetch PageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero, first T
+		for page := 1; ; page++ {
+			p, err := fetch(ctx, page, pageSize)
+			if err == nil && p.Meta == nil && len(p.Items) > 0 {
+				switch {
+				case page > 1 && reflect.DeepEqual(p.Items[0], first):
+					err = fmt.Errorf("page %d starts with the same item as page %d; the server seems to ignore the page parameter", page, page-1)
+				case page > maxPagesWithoutMeta:
+					err = fmt.Errorf("list exceeds %d pages", maxPagesWithoutMeta)
+				}
+				first = p.Items[0]
+			}
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if isLastPage(p, page, pageSize) {
+				return
+			}
+		}
+	}
+}
+
+// ListAll collects every item of a paginated list, so its memory use grows
+// with the length of the list. Use All to process items as pages arrive.
+func ListAll[T any](ctx context.Context, pageSize int, fetch PageFetcher[T]) ([]T, error) {
+	var items []T
+	for item, err := range All(ctx, pageSize, fetch) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// isLastPage reports whether p, fetched as page number page, is the final
+// page. Without metadata a short page marks the end of the list.
+func isLastPage[T any](p Page[T], page, pageSize int) bool {
+	if p.Meta != nil {
+		return page >= p.Meta.TotalPages
+	}
+	return len(p.Items) < pageSize || len(p.Items) == 0
+}
+
+// fetchPage performs GET apiPath?page=&limit= on the given API surface and
+// decodes the items found under itemsKey along with the optional "meta" object.
+// apiPath may carry further query parameters, e.g. filters. The page is
+// decoded while it is received, via streamList, and its items are collected
+// into the returned Page; memory use is therefore bounded by one page of
+// pageSize items, each page limited to maxResponseBodySize.
+func fetchPage[T any](ctx context.Context, c *Client, surface apiSurface, operation, apiPath, itemsKey string, page, limit int) (Page[T], error) {
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(page))
+	query.Set("limit", fmt.Sprint(limit))
+	sep := "?"
+	if strings.Contains(apiPath, "?") {
+		sep = "&"
+	}
+
+	var p Page[T]
+	meta, err := streamList(ctx, c, surface, operation, apiPath+sep+query.Encode(), itemsKey, func(item T) error {
+		p.Items = append(p.Items, item)
+		return nil
+	})
+	if err != nil {
+		return Page[T]{}, err
+	}
+	p.Meta = meta
+	return p, nil
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// pagedFetcher serves items in pages, with metadata if withMeta is set, and
+// records the pages requested.
+func pagedFetcher(items []int, withMeta bool, requested *[]int) PageFetcher[int] {
+	return func(ctx context.Context, page, limit int) (Page[int], error) {
+		*requested = append(*requested, page)
+		start := min((page-1)*limit, len(items))
+		p := Page[int]{Items: items[start:min(start+limit, len(items))]}
+		if withMeta {
+			p.Meta = &PageMeta{Page: page, Limit: limit, TotalItems: len(items), TotalPages: (len(items) + limit - 1) / limit}
+		}
+		return p, nil
+	}
+}
+
+func TestListAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		items     int
+		withMeta  bool
+		wantPages []int
+	}{
+		{name: "empty", items: 0, wantPages: []int{1}},
+		{name: "empty with meta", items: 0, withMeta: true, wantPages: []int{1}},
+		{name: "short page", items: 2, wantPages: []int{1}},
+		{name: "partial last page", items: 7, wantPages: []int{1, 2, 3}},
+		// Without metadata a full last page cannot be told apart from a
+		// page with more to follow, so an empty page ends the list.
+		{name: "page boundary", items: 6, wantPages: []int{1, 2, 3}},
+		{name: "page boundary with meta", items: 6, withMeta: true, wantPages: []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := make([]int, tt.items)
+			for i := range items {
+				items[i] = i
+			}
+			var requested []int
+			got, err := ListAll(context.Background(), 3, pagedFetcher(items, tt.withMeta, &requested))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.items || (tt.items > 0 && !slices.Equal(got, items)) {
+				t.Errorf("ListAll = %v, want %v", got, items)
+			}
+			if !slices.Equal(requested, tt.wantPages) {
+				t.Errorf("requested pages %v, want %v", requested, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestListAllError(t *testing.T) {
+	errPage := errors.New("page 2 failed")
+	fetch := func(ctx context.Context, page, limit int) (Page[int], error) {
+		if page == 2 {
+			return Page[int]{}, errPage
+		}
+		return Page[int]{Items: []int{1, 2}}, nil
+	}
+	if got, err := ListAll(context.Background(), 2, fetch); !errors.Is(err, errPage) || got != nil {
+		t.Errorf("ListAll = %v, %v; want no items and the page error", got, err)
+	}
+}
+
+func TestAllRepeatedPages(t *testing.T) {
+	tests := []struct {
+		name    string
+		fetch   PageFetcher[int]
+		wantErr string
+	}{
+		// The server ignores page and always returns the first one.
+		{name: "repeated page", fetch: func(ctx context.Context, page, limit int) (Page[int], error) {
+			return Page[int]{Items: []int{1, 2}}, nil
+		}, wantErr: "page 2 starts with the same item as page 1"},
+		// Pages cycle, so no page repeats the one right before it.
+		{name: "page cycle", fetch: func(ctx context.Context, page, limit int) (Page[int], error) {
+			return Page[int]{Items: []int{page % 2, 2}}, nil
+		}, wantErr: fmt.Sprintf("list exceeds %d pages", maxPagesWithoutMeta)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ListAll(context.Background(), 2, tt.fetch)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ListAll = %d items, %v; want error %q", len(got), err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAllStopsEarly(t *testing.T) {
+	var requested []int
+	for item, err := range All(context.Background(), 2, pagedFetcher([]int{0, 1, 2, 3, 4}, false, &requested)) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if item == 2 {
+			break
+		}
+	}
+	if !slices.Equal(requested, []int{1, 2}) {
+		t.Errorf("requested pages %v, want [1 2]", requested)
+	}
+}
+
+func TestFetchPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("status") != "PENDING" || q.Get("limit") != "2" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		switch page {
+		case 1:
+			fmt.Fprint(w, `{"data":[{"id":"a","name":"first"},{"id":"b","name":"second"}],"meta":{"page":1,"limit":2,"totalItems":3,"totalPages":2}}`)
+		case 2:
+			fmt.Fprint(w, `{"data":[{"id":"c","name":"third"}],"meta":{"page":2,"limit":2,"totalItems":3,"totalPages":2}}`)
+		default:
+			fmt.Fprint(w, `{"data":[],"meta":{"page":3,"limit":2,"totalItems":3,"totalPages":2}}`)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "admin-key")
+	fetch := func(ctx context.Context, page, limit int) (Page[Organization], error) {
+		return fetchPage[Organization](ctx, c, adminAPI, "list items", "/api/items?status=PENDING", "data", page, limit)
+	}
+	orgs, err := ListAll(context.Background(), 2, fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 3 || orgs[2].ID != "c" {
+		t.Errorf("got %v, want items a, b and c", orgs)
+	}
+
+	p, err := fetch(context.Background(), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Items) != 0 || p.Meta == nil || p.Meta.TotalItems != 3 {
+		t.Errorf("empty page = %+v, want no items with metadata", p)
+	}
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// PromptSelector picks the prompt version to fetch. At most one of Label and
+// Version should be set; when neither is, Langfuse returns the version
+// labeled "production".
+type PromptSelector struct {
+	Label   string
+	Version int
+}
+
+// GetPrompt calls GET /api/public/v2/prompts/{promptName}. Requires public
+// API credentials of the project owning the prompt. The returned error matches
+// ErrNotFound when no prompt version matches. The content of the returned
+// prompt is a JSON string for text prompts and a list of messages for chat
+// prompts, as told by its Type.
+func (c *Client) GetPrompt(ctx context.Context, name string, sel PromptSelector) (*Prompt, error) {
+	apiPath, err := escapePath("/api/public/v2/prompts/%s", name)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	if sel.Label != "" {
+		query.Set("label", sel.Label)
+	}
+	if sel.Version != 0 {
+		query.Set("version", strconv.Itoa(sel.Version))
+	}
+	if len(query) > 0 {
+		apiPath += "?" + query.Encode()
+	}
+
+	req, err := c.newRequest(ctx, publicAPI, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("get prompt", resp)
+	}
+	var p Prompt
+	if err := c.decodeJSON(ctx, "get prompt", resp.Body, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// promptLabelsUpdate is the body of PATCH
+// /api/public/v2/prompts/{name}/versions/{version}.
+type promptLabelsUpdate struct {
+	NewLabels []string `json:"newLabels"`
+}
+
+// SetPromptLabels calls PATCH /api/public/v2/prompts/{name}/versions/{version}
+// to add labels to a prompt version. Labels are unique across the versions of
+// a prompt, so Langfuse moves them from the version currently holding them.
+// Requires public API credentials of the project owning the prompt.
+func (c *Client) SetPromptLabels(ctx context.Context, name string, version int, labels []string) (*Prompt, error) {
+	apiPath, err := escapePath("/api/public/v2/prompts/%s/versions/%s", name, strconv.Itoa(version))
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, publicAPI, http.MethodPatch, apiPath, promptLabelsUpdate{NewLabels: labels})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("set prompt labels", resp)
+	}
+	var p Prompt
+	if err := c.decodeJSON(ctx, "set prompt labels", resp.Body, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
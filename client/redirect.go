@@ -0,0 +1,42 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DefaultMaxRedirects is the number of redirects followed unless
+// WithMaxRedirects overrides it.
+const DefaultMaxRedirects = 5
+
+// WithMaxRedirects sets how many redirects are followed per request. Zero
+// disables following redirects; the redirect response is returned as-is.
+func WithMaxRedirects(n int) Option {
+	return func(c *Client) {
+		c.maxRedirects = n
+	}
+}
+
+// checkRedirect returns the redirect policy of the client. Unlike the default
+// policy of net/http, which keeps credentials for subdomains of the original
+// host, the Authorization header is only forwarded to the exact host and port
+// of the original request. Redirects from https to plain http are refused.
+func checkRedirect(maxRedirects int) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if maxRedirects <= 0 {
+			return http.ErrUseLastResponse
+		}
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		orig := via[0]
+		if orig.URL.Scheme == "https" && req.URL.Scheme != "https" {
+			return fmt.Errorf("refusing redirect from https to insecure URL %s", req.URL.Redacted())
+		}
+		if req.URL.Host != orig.URL.Host {
+			req.Header.Del("Authorization")
+			req.Header.Del("Cookie")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCheckRedirect(t *testing.T) {
+	var gotAuth, gotCookie string
+	// /hop redirects to the URL in its "to" parameter, /loop to itself, and
+	// /final records the credentials it received.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hop":
+			http.Redirect(w, r, r.URL.Query().Get("to"), http.StatusTemporaryRedirect)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusTemporaryRedirect)
+		default:
+			gotAuth = r.Header.Get("Authorization")
+			gotCookie = r.Header.Get("Cookie")
+		}
+	})
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	tlsSrv := httptest.NewTLSServer(handler)
+	defer tlsSrv.Close()
+	// net/http itself keeps credentials for another port of the same host.
+	otherPort := httptest.NewServer(handler)
+	defer otherPort.Close()
+	// The same server under another host name, which is a different origin.
+	otherHost := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	hop := func(from, to string) string {
+		return from + "/hop?to=" + url.QueryEscape(to)
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		maxRedirects int
+		wantStatus   int
+		wantCreds    bool
+		wantErr      string
+	}{
+		{name: "same host", url: hop(srv.URL, srv.URL+"/final"), maxRedirects: 5, wantStatus: http.StatusOK, wantCreds: true},
+		{name: "cross host", url: hop(srv.URL, otherHost+"/final"), maxRedirects: 5, wantStatus: http.StatusOK},
+		{name: "cross port", url: hop(srv.URL, otherPort.URL+"/final"), maxRedirects: 5, wantStatus: http.StatusOK},
+		{name: "https to https", url: hop(tlsSrv.URL, tlsSrv.URL+"/final"), maxRedirects: 5, wantStatus: http.StatusOK, wantCreds: true},
+		{name: "https to http", url: hop(tlsSrv.URL, srv.URL+"/final"), maxRedirects: 5, wantErr: "refusing redirect from https to insecure URL"},
+		{name: "disabled", url: hop(srv.URL, srv.URL+"/final"), maxRedirects: 0, wantStatus: http.StatusTemporaryRedirect},
+		{name: "too many", url: srv.URL + "/loop", maxRedirects: 3, wantErr: "stopped after 3 redirects"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotAuth, gotCookie = "", ""
+			c := &http.Client{Transport: tlsSrv.Client().Transport, CheckRedirect: checkRedirect(tt.maxRedirects)}
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("Authorization", "Bearer admin-key")
+			req.Header.Set("Cookie", "session=abc")
+			resp, err := c.Do(req)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && ((gotAuth != "") != tt.wantCreds || (gotCookie != "") != tt.wantCreds) {
+				t.Errorf("Authorization %q and Cookie %q reached the target, want forwarded: %t", gotAuth, gotCookie, tt.wantCreds)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the initial attempt. Zero disables retries.
+	MaxRetries int
+	// MinBackoff is the wait before the first retry; it doubles on each attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts, including waits the
+	// server requests with Retry-After.
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait between zero and the computed backoff so that
+	// many clients retrying at once do not synchronize.
+	Jitter bool
+	// MaxElapsedTime bounds the total time spent retrying a single request.
+	// Zero means no bound other than MaxRetries and the request context.
+	MaxElapsedTime time.Duration
+}
+
+// DefaultRetryPolicy is used unless WithRetryPolicy overrides it.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:     3,
+	MinBackoff:     500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Jitter:         true,
+	MaxElapsedTime: 2 * time.Minute,
+}
+
+// WithRetryPolicy replaces the client's retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// retryTransport is an http.RoundTripper that retries transient failures of
+// the wrapped transport according to policy.
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(withAttempt(ctx, attempt))
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxRetries || !canReplay || !shouldRetry(req.Method, resp, err) {
+			return resp, err
+		}
+		wait := t.policy.backoff(attempt+1, resp)
+		if t.policy.MaxElapsedTime > 0 && time.Since(start)+wait > t.policy.MaxElapsedTime {
+			return resp, err
+		}
+		if resp != nil {
+			drainAndClose(resp.Body)
+		}
+
+		tflog.Debug(ctx, "Retrying Langfuse API request", map[string]interface{}{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"attempt": attempt + 1,
+			"wait_ms": wait.Milliseconds(),
+		})
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// attemptKey carries the 0-based attempt number of a request in its context.
+type attemptKey struct{}
+
+// withAttempt records attempt in ctx.
+func withAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+// attemptNumber returns the attempt recorded in ctx; the first attempt is 0.
+func attemptNumber(ctx context.Context) int {
+	n, _ := ctx.Value(attemptKey{}).(int)
+	return n
+}
+
+// shouldRetry reports whether a request with the given method that ended with
+// resp or err may be sent again. Requests rejected with 429 were not processed
+// and are always retried; other failures only for idempotent methods.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+	default:
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt (starting at 1),
+// honoring a Retry-After header when the server sent one. Retry-After is
+// capped at MaxBackoff like any other wait, so a server cannot stall the
+// client indefinitely.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			// Compared in seconds, as huge values overflow a Duration.
+			if p.MaxBackoff > 0 && int64(secs) > int64(p.MaxBackoff/time.Second) {
+				return p.MaxBackoff
+			}
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	if p.Jitter && wait > 0 {
+		wait = time.Duration(rand.Int63n(int64(wait) + 1))
+	}
+	return wait
+}
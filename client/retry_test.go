@@ -0,0 +1,160 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	errReset := errors.New("connection reset")
+	tests := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{http.MethodGet, http.StatusTooManyRequests, nil, true},
+		{http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{http.MethodPatch, http.StatusTooManyRequests, nil, true},
+		{http.MethodGet, http.StatusBadGateway, nil, true},
+		{http.MethodGet, http.StatusServiceUnavailable, nil, true},
+		{http.MethodDelete, http.StatusGatewayTimeout, nil, true},
+		{http.MethodPut, http.StatusServiceUnavailable, nil, true},
+		{http.MethodGet, http.StatusInternalServerError, nil, false},
+		{http.MethodGet, http.StatusNotFound, nil, false},
+		{http.MethodGet, http.StatusOK, nil, false},
+		{http.MethodPost, http.StatusServiceUnavailable, nil, false},
+		{http.MethodPatch, http.StatusBadGateway, nil, false},
+		{http.MethodGet, 0, errReset, true},
+		{http.MethodPost, 0, errReset, false},
+	}
+	for _, tt := range tests {
+		var resp *http.Response
+		if tt.err == nil {
+			resp = &http.Response{StatusCode: tt.status}
+		}
+		if got := shouldRetry(tt.method, resp, tt.err); got != tt.want {
+			t.Errorf("shouldRetry(%s, %d, %v) = %t, want %t", tt.method, tt.status, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	tests := []struct {
+		name         string
+		method       string
+		statuses     []int
+		wantAttempts int
+		wantStatus   int
+	}{
+		{name: "success", method: http.MethodGet, statuses: []int{200}, wantAttempts: 1, wantStatus: 200},
+		{name: "transient get", method: http.MethodGet, statuses: []int{503, 200}, wantAttempts: 2, wantStatus: 200},
+		{name: "retries exhausted", method: http.MethodGet, statuses: []int{502, 502, 502, 200}, wantAttempts: 3, wantStatus: 502},
+		{name: "server error", method: http.MethodGet, statuses: []int{500, 200}, wantAttempts: 1, wantStatus: 500},
+		{name: "not found", method: http.MethodDelete, statuses: []int{404, 200}, wantAttempts: 1, wantStatus: 404},
+		{name: "transient post", method: http.MethodPost, statuses: []int{503, 201}, wantAttempts: 1, wantStatus: 503},
+		{name: "rate limited post", method: http.MethodPost, statuses: []int{429, 201}, wantAttempts: 2, wantStatus: 201},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1)) - 1
+				if body, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(body) != `{"name":"team"}` {
+					t.Errorf("attempt %d sent body %q", n, body)
+				}
+				w.WriteHeader(tt.statuses[n])
+			}))
+			defer srv.Close()
+
+			req, _ := http.NewRequest(tt.method, srv.URL, nil)
+			if tt.method == http.MethodPost {
+				req, _ = http.NewRequest(tt.method, srv.URL, strings.NewReader(`{"name":"team"}`))
+			}
+			tr := &retryTransport{next: http.DefaultTransport, policy: policy}
+			resp, err := tr.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus || int(attempts.Load()) != tt.wantAttempts {
+				t.Errorf("got status %d after %d attempts, want %d after %d", resp.StatusCode, attempts.Load(), tt.wantStatus, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryTransportMaxElapsedTime(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	// Waiting as long as the server asks would exceed MaxElapsedTime, so the
+	// response is returned right away instead.
+	tr := &retryTransport{next: http.DefaultTransport, policy: RetryPolicy{MaxRetries: 3, MaxElapsedTime: time.Second}}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	start := time.Now()
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || attempts.Load() != 1 {
+		t.Errorf("got status %d after %d attempts, want 503 after 1", resp.StatusCode, attempts.Load())
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, want no wait", elapsed)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{name: "first", attempt: 1, want: 100 * time.Millisecond},
+		{name: "doubled", attempt: 3, want: 400 * time.Millisecond},
+		{name: "capped", attempt: 10, want: 10 * time.Second},
+		{name: "retry after", attempt: 1, retryAfter: "7", want: 7 * time.Second},
+		{name: "retry after capped", attempt: 1, retryAfter: "3600", want: 10 * time.Second},
+		{name: "huge retry after", attempt: 1, retryAfter: "99999999999", want: 10 * time.Second},
+		{name: "retry after zero", attempt: 3, retryAfter: "0", want: 0},
+		{name: "negative retry after", attempt: 2, retryAfter: "-1", want: 200 * time.Millisecond},
+		{name: "malformed retry after", attempt: 2, retryAfter: "soon", want: 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+			if got := p.backoff(tt.attempt, resp); got != tt.want {
+				t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+			}
+		})
+	}
+
+	p.Jitter = true
+	for attempt := 1; attempt <= 5; attempt++ {
+		limit := min(p.MinBackoff<<(attempt-1), p.MaxBackoff)
+		for range 100 {
+			if got := p.backoff(attempt, nil); got < 0 || got > limit {
+				t.Fatalf("jittered backoff(%d) = %s, want within [0, %s]", attempt, got, limit)
+			}
+		}
+	}
+}
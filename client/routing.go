@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// apiSurface selects which Langfuse API a request targets. The admin API
+// (/api/admin/...) authenticates with the Admin API key as a bearer token; the
+// public API (/api/public/...) with a project key pair via basic auth.
+type apiSurface int
+
+const (
+	adminAPI apiSurface = iota
+	publicAPI
+)
+
+// ErrNoPublicAPICredentials is returned by public API calls when the client
+// was created without WithPublicAPICredentials.
+var ErrNoPublicAPICredentials = errors.New("public API credentials (public and secret key) are not configured")
+
+// WithPublicAPICredentials sets the project key pair used for requests to the
+// public API. The Admin API key passed to NewClient is only used for the admin API.
+func WithPublicAPICredentials(publicKey, secretKey string) Option {
+	return func(c *Client) {
+		c.publicKey = publicKey
+		c.secretKey = secretKey
+	}
+}
+
+// HasPublicAPICredentials reports whether public API calls can be made.
+func (c *Client) HasPublicAPICredentials() bool {
+	return c.publicKey != "" && c.secretKey != ""
+}
+
+// newRequest builds a request for apiPath on the given API surface, encoding
+// body as JSON when it is non-nil and attaching the matching credentials.
+func (c *Client) newRequest(ctx context.Context, surface apiSurface, method, apiPath string, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint(apiPath), r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	switch surface {
+	case adminAPI:
+		req.Header.Set("Authorization", "Bearer "+c.adminKey)
+	case publicAPI:
+		if !c.HasPublicAPICredentials() {
+			return nil, ErrNoPublicAPICredentials
+		}
+		req.SetBasicAuth(c.publicKey, c.secretKey)
+	}
+	return req, nil
+}
+
+// escapePath substitutes params for the %s verbs in format, escaping each as a
+// single path segment. Empty params and the dot segments "." and "..", which
+// would address a different endpoint once the path is normalized, are rejected.
+func escapePath(format string, params ...string) (string, error) {
+	args := make([]any, len(params))
+	for i, p := range params {
+		switch p {
+		case "", ".", "..":
+			return "", fmt.Errorf("invalid path parameter %q", p)
+		}
+		args[i] = url.PathEscape(p)
+	}
+	return fmt.Sprintf(format, args...), nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEscapePath(t *testing.T) {
+	tests := []struct {
+		format  string
+		params  []string
+		want    string
+		wantErr bool
+	}{
+		{format: "/api/admin/organizations/%s", params: []string{"org-1"}, want: "/api/admin/organizations/org-1"},
+		{format: "/api/admin/organizations/%s/projects/%s", params: []string{"org-1", "proj-1"}, want: "/api/admin/organizations/org-1/projects/proj-1"},
+		{format: "/api/public/v2/prompts/%s", params: []string{"team/greeting"}, want: "/api/public/v2/prompts/team%2Fgreeting"},
+		{format: "/api/public/v2/prompts/%s", params: []string{"a b?c#d"}, want: "/api/public/v2/prompts/a%20b%3Fc%23d"},
+		{format: "/api/public/v2/prompts/%s", params: []string{"..."}, want: "/api/public/v2/prompts/..."},
+		{format: "/api/public/v2/prompts/%s", params: []string{"%2e%2e"}, want: "/api/public/v2/prompts/%252e%252e"},
+		{format: "/api/admin/organizations/%s", params: []string{""}, wantErr: true},
+		{format: "/api/admin/organizations/%s", params: []string{"."}, wantErr: true},
+		{format: "/api/admin/organizations/%s", params: []string{".."}, wantErr: true},
+		{format: "/api/admin/organizations/%s/projects/%s", params: []string{"org-1", ".."}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := escapePath(tt.format, tt.params...)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("escapePath(%q, %q) = %q, want error", tt.format, tt.params, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("escapePath(%q, %q) = %q, %v; want %q", tt.format, tt.params, got, err, tt.want)
+		}
+	}
+}
+
+// TestDotSegmentsNotSent checks that a dot segment ID fails before any request
+// is sent, rather than addressing the parent collection.
+func TestDotSegmentsNotSent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "admin-key")
+	for _, id := range []string{"", ".", ".."} {
+		err := c.DeleteProject(context.Background(), "org-1", id)
+		if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "invalid path parameter") {
+			t.Errorf("DeleteProject with ID %q returned %v, want an invalid path parameter error", id, err)
+		}
+	}
+}
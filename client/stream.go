@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// streamingKey marks a request context whose response is decoded
+// incrementally and therefore must not be buffered by the transports.
+type streamingKey struct{}
+
+// withStreaming marks ctx so that responses to requests made with it are
+// passed through unbuffered.
+func withStreaming(ctx context.Context) context.Context {
+	return context.WithValue(ctx, streamingKey{}, true)
+}
+
+// isStreaming reports whether ctx was marked by withStreaming.
+func isStreaming(ctx context.Context) bool {
+	v, _ := ctx.Value(streamingKey{}).(bool)
+	return v
+}
+
+// decodeItems reads a JSON object from r and calls fn for each element of the
+// array found under itemsKey as soon as it is decoded, so only one item is
+// held in memory at a time. The "meta" object is decoded and returned if
+// present; all other fields are skipped. Items are decoded with unmarshal.
+func decodeItems[T any](r io.Reader, itemsKey string, unmarshal func([]byte, any) error, fn func(T) error) (*PageMeta, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var meta *PageMeta
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		switch key {
+		case itemsKey:
+			if err := decodeArray(dec, unmarshal, fn); err != nil {
+				return nil, fmt.Errorf("decoding %q: %w", itemsKey, err)
+			}
+		case "meta":
+			var m *PageMeta
+			if err := dec.Decode(&m); err != nil {
+				return nil, fmt.Errorf("decoding \"meta\": %w", err)
+			}
+			meta = m
+		default:
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// decodeArray decodes a JSON array element by element using unmarshal,
+// passing each to fn. A null value is treated as an empty array.
+func decodeArray[T any](dec *json.Decoder, unmarshal func([]byte, any) error, fn func(T) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		var item T
+		if err := unmarshal(raw, &item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim consumes the next token and fails unless it is want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// streamList performs GET apiPath on the given API surface and calls fn for
+// every item under itemsKey while the response is still being received, then
+// returns the "meta" object of the response, if any. The response is not
+// buffered for logging, and the decoder holds one item at a time; what fn
+// keeps is up to the caller. The body is still subject to
+// maxResponseBodySize, so a single response cannot grow without bound.
+// Returning an error from fn aborts the transfer.
+func streamList[T any](ctx context.Context, c *Client, surface apiSurface, operation, apiPath, itemsKey string, fn func(T) error) (*PageMeta, error) {
+	req, err := c.newRequest(withStreaming(ctx), surface, http.MethodGet, apiPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError(operation, resp)
+	}
+	body := &limitedReader{r: resp.Body, remaining: maxResponseBodySize}
+	meta, err := decodeItems(body, itemsKey, c.unmarshaler(ctx, operation), fn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	return meta, nil
+}
+
+// limitedReader reads from r until remaining bytes have been read and fails
+// with the error of readBody beyond that, rather than reporting a truncated
+// document as io.LimitReader would.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read implements io.Reader.
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, fmt.Errorf("response body exceeds %d bytes", maxResponseBodySize)
+	}
+	// Read one byte beyond the limit to tell a body of exactly the limit
+	// from a longer one.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return 0, fmt.Errorf("response body exceeds %d bytes", maxResponseBodySize)
+	}
+	return n, err
+}
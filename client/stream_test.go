@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// TestStreamListDoesNotBuffer checks that list responses reach the decoder
+// while they are still being received, even with body logging enabled: the
+// server holds back the end of the page until the first item was decoded.
+func TestStreamListDoesNotBuffer(t *testing.T) {
+	decoded := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"organizations":[{"id":"org-1","name":"first"}`)
+		w.(http.Flusher).Flush()
+		select {
+		case <-decoded:
+		case <-time.After(5 * time.Second):
+			t.Error("first item was not decoded before the response was complete")
+		}
+		fmt.Fprint(w, `,{"id":"org-2","name":"second"}],"meta":{"page":1,"limit":50,"totalItems":2,"totalPages":1}}`)
+	}))
+	defer srv.Close()
+
+	t.Setenv(BodyLogEnvVar, "TRACE")
+	ctx := tflogtest.RootLogger(context.Background(), &bytes.Buffer{})
+	c := NewClient(srv.URL, "admin-key")
+	var names []string
+	meta, err := streamList(ctx, c, adminAPI, "list organizations", "/api/admin/organizations", "organizations", func(org Organization) error {
+		if len(names) == 0 {
+			close(decoded)
+		}
+		names = append(names, org.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || meta == nil || meta.TotalItems != 2 {
+		t.Errorf("got items %v and meta %+v, want two items and totalItems 2", names, meta)
+	}
+}
+
+func TestStreamListBodyLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"organizations":[{"id":"org-1","name":"first"}`+strings.Repeat(" ", maxResponseBodySize)+`]}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "admin-key")
+	var n int
+	_, err := streamList(context.Background(), c, adminAPI, "list organizations", "/api/admin/organizations", "organizations", func(org Organization) error {
+		n++
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "response body exceeds") {
+		t.Errorf("streamList = %v after %d items, want the body limit error", err, n)
+	}
+}
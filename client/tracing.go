@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies spans created by the client.
+const tracerName = "github.com/faxe1008/terraform-provider-langfuse/client"
+
+// routeParams maps collection path segments to the placeholder used for the
+// identifier that follows them, keeping span names low-cardinality.
+var routeParams = map[string]string{
+	"organizations":     "{orgId}",
+	"projects":          "{projectId}",
+	"apiKeys":           "{apiKeyId}",
+	"prompts":           "{promptName}",
+	"versions":          "{version}",
+	"datasets":          "{datasetName}",
+	"runs":              "{runName}",
+	"annotation-queues": "{queueId}",
+	"media":             "{mediaId}",
+}
+
+// tracingHook is a Hook that records an OpenTelemetry span per request.
+type tracingHook struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracingHook returns a Hook that records a client span for every request
+// attempt using tp and propagates the trace context to the server via W3C
+// trace context headers.
+func NewTracingHook(tp trace.TracerProvider) Hook {
+	return &tracingHook{
+		tracer:     tp.Tracer(tracerName),
+		propagator: propagation.TraceContext{},
+	}
+}
+
+// OnRequest implements Hook.
+func (h *tracingHook) OnRequest(ctx context.Context, req *http.Request) context.Context {
+	route := routeTemplate(req.URL.EscapedPath())
+	ctx, _ = h.tracer.Start(ctx, req.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.HTTPRoute(route),
+			semconv.URLPath(req.URL.Path),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	h.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return ctx
+}
+
+// OnResponse implements Hook.
+func (h *tracingHook) OnResponse(ctx context.Context, req *http.Request, resp *http.Response, err error, elapsed time.Duration) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(attribute.Int64("langfuse.duration_ms", elapsed.Milliseconds()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+// routeTemplate replaces identifiers in an API path with placeholders, e.g.
+// /api/admin/organizations/abc becomes /api/admin/organizations/{orgId}.
+func routeTemplate(p string) string {
+	segments := strings.Split(p, "/")
+	for i := 1; i < len(segments); i++ {
+		if param, ok := routeParams[segments[i-1]]; ok && segments[i] != "" {
+			segments[i] = param
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRouteTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/admin/organizations", "/api/admin/organizations"},
+		{"/api/admin/organizations/", "/api/admin/organizations/"},
+		{"/api/admin/organizations/org-1", "/api/admin/organizations/{orgId}"},
+		{"/api/admin/organizations/org-1/projects", "/api/admin/organizations/{orgId}/projects"},
+		{"/api/admin/organizations/org-1/projects/proj-1", "/api/admin/organizations/{orgId}/projects/{projectId}"},
+		{"/api/admin/organizations/org-1/projects/proj-1/apiKeys", "/api/admin/organizations/{orgId}/projects/{projectId}/apiKeys"},
+		{"/api/admin/organizations/org-1/projects/proj-1/apiKeys/key-1", "/api/admin/organizations/{orgId}/projects/{projectId}/apiKeys/{apiKeyId}"},
+		{"/api/public/v2/prompts/greeting", "/api/public/v2/prompts/{promptName}"},
+		{"/api/public/v2/prompts/team%2Fgreeting", "/api/public/v2/prompts/{promptName}"},
+		{"/api/public/v2/prompts/greeting/versions/2", "/api/public/v2/prompts/{promptName}/versions/{version}"},
+		{"/api/public/datasets/qa/runs/nightly", "/api/public/datasets/{datasetName}/runs/{runName}"},
+		{"/api/public/annotation-queues/queue-1/items", "/api/public/annotation-queues/{queueId}/items"},
+		{"/api/public/media", "/api/public/media"},
+		{"/api/public/media/media-1", "/api/public/media/{mediaId}"},
+		{"/api/public/health", "/api/public/health"},
+		{"/langfuse/api/admin/organizations/org-1", "/langfuse/api/admin/organizations/{orgId}"},
+	}
+	for _, tt := range tests {
+		if got := routeTemplate(tt.path); got != tt.want {
+			t.Errorf("routeTemplate(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestTracingHookLeavesRequestUntouched(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	var sent http.Header
+	tr := &hookTransport{
+		hooks: []Hook{NewTracingHook(tp)},
+		next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			sent = req.Header
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}),
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://langfuse.example.com/api/admin/organizations/org-1", nil)
+	req.Header.Set("Accept", "application/json")
+	if _, err := tr.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent.Get("traceparent") == "" {
+		t.Error("trace context was not propagated")
+	}
+	if len(req.Header) != 1 {
+		t.Errorf("caller's request headers were modified: %v", req.Header)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "GET /api/admin/organizations/{orgId}" {
+		t.Errorf("recorded spans %v, want one named after the route", spans)
+	}
+}
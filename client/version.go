@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Capability is an API feature that is only available from a given Langfuse
+// version on.
+type Capability struct {
+	Name       string
+	MinVersion string
+}
+
+// CapabilityOrganizationManagement covers the admin organization and project
+// management endpoints.
+var CapabilityOrganizationManagement = Capability{
+	Name:       "organization management API",
+	MinVersion: "3.0.0",
+}
+
+// UnsupportedError is returned when the instance is too old for a capability.
+type UnsupportedError struct {
+	Capability Capability
+	// Version is the version reported by the instance.
+	Version string
+}
+
+// Error implements error.
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("the %s requires Langfuse >= %s, but the instance reports version %s", e.Capability.Name, e.Capability.MinVersion, e.Version)
+}
+
+// ServerInfo calls GET /api/public/health. The result is cached for the
+// lifetime of the client.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	if c.info != nil {
+		info := *c.info
+		return &info, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint("/api/public/health"), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndClose(resp.Body)
+	if resp.StatusCode >= 300 {
+		return nil, newAPIError("get server info", resp)
+	}
+	var info ServerInfo
+	if err := c.decodeJSON(ctx, "get server info", resp.Body, &info); err != nil {
+		return nil, err
+	}
+	c.info = &info
+	out := info
+	return &out, nil
+}
+
+// CheckCapability returns an *UnsupportedError when the instance reports a
+// version older than the capability requires. Instances that do not report a
+// parseable version are assumed to support it.
+func (c *Client) CheckCapability(ctx context.Context, capability Capability) error {
+	info, err := c.ServerInfo(ctx)
+	if err != nil {
+		return err
+	}
+	if info.Version == "" {
+		return nil
+	}
+	if cmp, ok := compareVersions(info.Version, capability.MinVersion); ok && cmp < 0 {
+		return &UnsupportedError{Capability: capability, Version: info.Version}
+	}
+	return nil
+}
+
+// explainNotFound turns a 404 from an endpoint that belongs to capability into
+// an *UnsupportedError when the instance is too old to provide it. Any other
+// error is returned unchanged.
+func (c *Client) explainNotFound(ctx context.Context, capability Capability, err error) error {
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if capErr := c.CheckCapability(ctx, capability); capErr != nil {
+		var unsupported *UnsupportedError
+		if errors.As(capErr, &unsupported) {
+			return unsupported
+		}
+	}
+	return err
+}
+
+// compareVersions compares two dotted versions such as "3.12.0" or "v3.12.0-rc1",
+// ignoring pre-release and build suffixes. ok is false if either is malformed.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, true
+		case pa[i] > pb[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// parseVersion extracts major, minor and patch numbers from v.
+func parseVersion(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{a: "3.12.0", b: "3.12.0", want: 0, wantOK: true},
+		{a: "3.12.1", b: "3.12.0", want: 1, wantOK: true},
+		{a: "3.12.0", b: "3.12.1", want: -1, wantOK: true},
+		// Components compare numerically, not as strings.
+		{a: "3.9.10", b: "3.9.9", want: 1, wantOK: true},
+		{a: "3.10.0", b: "3.9.99", want: 1, wantOK: true},
+		{a: "2.99.99", b: "3.0.0", want: -1, wantOK: true},
+		// Missing components count as zero.
+		{a: "3.12", b: "3.12.0", want: 0, wantOK: true},
+		{a: "3", b: "3.0.1", want: -1, wantOK: true},
+		// A leading v and surrounding whitespace are ignored.
+		{a: " v3.12.0 ", b: "3.12.0", want: 0, wantOK: true},
+		// Pre-release and build suffixes are ignored, so a release candidate
+		// counts as the release it precedes.
+		{a: "3.12.0-rc1", b: "3.12.0", want: 0, wantOK: true},
+		{a: "3.12.0-rc1", b: "3.12.1", want: -1, wantOK: true},
+		{a: "3.12.1-beta.2", b: "3.12.0", want: 1, wantOK: true},
+		{a: "3.12.0+build.7", b: "3.12.0-rc1", want: 0, wantOK: true},
+		{a: "latest", b: "3.12.0"},
+		{a: "3.12.0", b: ""},
+		{a: "3.12.0.1", b: "3.12.0"},
+		{a: "3.x", b: "3.12.0"},
+		{a: "3.-1.0", b: "3.12.0"},
+	}
+	for _, tt := range tests {
+		got, ok := compareVersions(tt.a, tt.b)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, %t; want %d, %t", tt.a, tt.b, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestCheckCapability(t *testing.T) {
+	capability := Capability{Name: "admin API", MinVersion: "3.12.0"}
+	tests := []struct {
+		version     string
+		unsupported bool
+	}{
+		{version: "3.12.0"},
+		{version: "v3.13.2"},
+		{version: "3.12.0-rc1"},
+		{version: "3.11.9", unsupported: true},
+		{version: ""},
+		{version: "dev"},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"status":"OK","version":%q}`, tt.version)
+		}))
+		err := NewClient(srv.URL, "admin-key").CheckCapability(context.Background(), capability)
+		srv.Close()
+
+		var unsupported *UnsupportedError
+		if errors.As(err, &unsupported) != tt.unsupported || (!tt.unsupported && err != nil) {
+			t.Errorf("CheckCapability with version %q = %v, want unsupported: %t", tt.version, err, tt.unsupported)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promotePromptAction implements the langfuse_promote_prompt action.
+type promotePromptAction struct {
+	client client.LangfuseAPI
+}
+
+// NewPromotePromptAction returns a new promotePromptAction.
+func NewPromotePromptAction() action.Action {
+	return &promotePromptAction{}
+}
+
+var (
+	_ action.ActionWithConfigure      = &promotePromptAction{}
+	_ action.ActionWithValidateConfig = &promotePromptAction{}
+)
+
+// latestLabel is maintained by Langfuse on the newest version of every
+// prompt and cannot be assigned.
+const latestLabel = "latest"
+
+// Metadata sets the action type name.
+func (a *promotePromptAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_promote_prompt"
+}
+
+// Schema defines the langfuse_promote_prompt action schema.
+func (a *promotePromptAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns labels to a prompt version, e.g. promotes a tested version to `production`. Labels are unique across the versions of a prompt, so Langfuse moves them from the version currently holding them. Requires `public_key` and `secret_key` of the project owning the prompt in the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the prompt.",
+			},
+			"version": schema.Int64Attribute{
+				Required:    true,
+				Description: "Version to promote.",
+			},
+			"labels": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Labels to assign to the version, e.g. `[\"production\"]`. Labels already on the version are kept. `latest` is maintained by Langfuse and cannot be assigned.",
+			},
+		},
+	}
+}
+
+// promotePromptActionModel maps the langfuse_promote_prompt action schema.
+type promotePromptActionModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.Int64  `tfsdk:"version"`
+	Labels  types.List   `tfsdk:"labels"`
+}
+
+// ValidateConfig checks the version and labels when they are known.
+func (a *promotePromptAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var data promotePromptActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.Version.IsNull() && !data.Version.IsUnknown() && data.Version.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid prompt version",
+			fmt.Sprintf("Prompt versions start at 1, got %d.", data.Version.ValueInt64()))
+	}
+	if data.Labels.IsNull() || data.Labels.IsUnknown() {
+		return
+	}
+	var labels []types.String
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if len(labels) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("labels"), "Missing prompt labels", "`labels` must contain at least one label.")
+	}
+	for i, l := range labels {
+		switch {
+		case l.IsUnknown() || l.IsNull():
+		case strings.TrimSpace(l.ValueString()) == "":
+			resp.Diagnostics.AddAttributeError(path.Root("labels").AtListIndex(i), "Invalid prompt label", "Labels must not be empty.")
+		case l.ValueString() == latestLabel:
+			resp.Diagnostics.AddAttributeError(path.Root("labels").AtListIndex(i), "Invalid prompt label",
+				"The `latest` label is maintained by Langfuse on the newest version and cannot be assigned.")
+		}
+	}
+}
+
+// Configure injects the Langfuse client.
+func (a *promotePromptAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	a.client = clientData
+}
+
+// Invoke assigns the labels to the prompt version.
+func (a *promotePromptAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	defer logClientMetrics(ctx, a.client)
+	var data promotePromptActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	var labels []string
+	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	name, version := data.Name.ValueString(), int(data.Version.ValueInt64())
+
+	prompt, err := a.client.SetPromptLabels(ctx, name, version, labels)
+	if err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("Error promoting version %d of prompt %s", version, name), err)
+		return
+	}
+	for _, l := range labels {
+		if !slices.Contains(prompt.Labels, l) {
+			resp.Diagnostics.AddWarning("Prompt label not assigned",
+				fmt.Sprintf("Langfuse accepted the request, but version %d of prompt %s is not labeled %q; its labels are %s.", version, name, l, strings.Join(prompt.Labels, ", ")))
+		}
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Version %d of prompt %s is labeled %s.", version, name, strings.Join(labels, ", ")),
+	})
+}
@@ -0,0 +1,75 @@
+package langfuse
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promotePromptConfig returns a langfuse_promote_prompt configuration.
+func promotePromptConfig(t *testing.T, name string, version int64, labels ...string) promotePromptActionModel {
+	t.Helper()
+	l, diags := types.ListValueFrom(context.Background(), types.StringType, labels)
+	requireNoErrors(t, diags)
+	return promotePromptActionModel{Name: types.StringValue(name), Version: types.Int64Value(version), Labels: l}
+}
+
+func TestPromotePromptActionInvoke(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	fake.AddPrompt(client.Prompt{Name: "greeting", Version: 1, Labels: []string{"production"}})
+	fake.AddPrompt(client.Prompt{Name: "greeting", Version: 2, Labels: []string{"staging"}})
+	a := &promotePromptAction{client: fake}
+
+	resp, progress := invokeAction(t, a, promotePromptConfig(t, "greeting", 2, "production"))
+	requireNoErrors(t, resp.Diagnostics)
+	if len(progress) != 1 {
+		t.Errorf("got progress %q, want one message", progress)
+	}
+	got, err := fake.GetPrompt(ctx, "greeting", client.PromptSelector{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != 2 || !slices.Equal(got.Labels, []string{"staging", "production"}) {
+		t.Errorf("production is version %d labeled %q, want version 2 labeled staging, production", got.Version, got.Labels)
+	}
+	old, _ := fake.GetPrompt(ctx, "greeting", client.PromptSelector{Version: 1})
+	if len(old.Labels) != 0 {
+		t.Errorf("version 1 kept labels %q", old.Labels)
+	}
+
+	resp, _ = invokeAction(t, a, promotePromptConfig(t, "greeting", 3, "production"))
+	requireError(t, resp.Diagnostics, "Error promoting version 3 of prompt greeting")
+}
+
+func TestPromotePromptActionValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		version int64
+		labels  []string
+		wantErr string
+	}{
+		{name: "valid", version: 1, labels: []string{"production", "canary"}},
+		{name: "version zero", version: 0, labels: []string{"production"}, wantErr: "Invalid prompt version"},
+		{name: "no labels", version: 1, labels: []string{}, wantErr: "Missing prompt labels"},
+		{name: "empty label", version: 1, labels: []string{" "}, wantErr: "Invalid prompt label"},
+		{name: "latest", version: 1, labels: []string{"latest"}, wantErr: "Invalid prompt label"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &promotePromptAction{}
+			var resp action.ValidateConfigResponse
+			a.ValidateConfig(context.Background(), action.ValidateConfigRequest{Config: actionConfig(t, a, promotePromptConfig(t, "greeting", tt.version, tt.labels...))}, &resp)
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rotateProjectKeysAction implements the langfuse_rotate_project_keys action.
+type rotateProjectKeysAction struct {
+	client client.LangfuseAPI
+}
+
+// NewRotateProjectKeysAction returns a new rotateProjectKeysAction.
+func NewRotateProjectKeysAction() action.Action {
+	return &rotateProjectKeysAction{}
+}
+
+var _ action.ActionWithConfigure = &rotateProjectKeysAction{}
+
+// Metadata sets the action type name.
+func (a *rotateProjectKeysAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rotate_project_keys"
+}
+
+// Schema defines the langfuse_rotate_project_keys action schema.
+func (a *rotateProjectKeysAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Rotates the API keys of a project: creates a new key pair, hands its secret key to `secret_key_command` and then revokes the keys that existed before. Actions cannot return values, so the command is the only way to obtain the new secret key. Keys managed by `langfuse_api_key` resources are revoked as well and recreated by the next apply; the key in the state of `langfuse_project` is not updated.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the organization owning the project.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project whose keys are rotated.",
+			},
+			"note": schema.StringAttribute{
+				Optional:    true,
+				Description: "Note stored with the new key, e.g. the date of the rotation.",
+			},
+			"secret_key_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Command (program followed by its arguments) receiving the new secret key on standard input and `LANGFUSE_PROJECT_ID`, `LANGFUSE_ORGANIZATION_ID` and `LANGFUSE_PUBLIC_KEY` in its environment, e.g. `[\"vault\", \"kv\", \"put\", \"secret/langfuse/app\", \"secret_key=-\"]`. If it fails, the new key is revoked again and the existing keys are kept.",
+			},
+			"revoke_existing": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether the keys that existed before the rotation are revoked once the new key is delivered. Set to false to revoke them later, after every consumer switched to the new key. Defaults to true.",
+			},
+		},
+	}
+}
+
+// rotateProjectKeysActionModel maps the langfuse_rotate_project_keys action schema.
+type rotateProjectKeysActionModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	Note           types.String `tfsdk:"note"`
+	SecretKeyCmd   types.List   `tfsdk:"secret_key_command"`
+	RevokeExisting types.Bool   `tfsdk:"revoke_existing"`
+}
+
+// Configure injects the Langfuse client.
+func (a *rotateProjectKeysAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	a.client = clientData
+}
+
+// Invoke creates and delivers the new key, then revokes the previous ones.
+func (a *rotateProjectKeysAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	defer logClientMetrics(ctx, a.client)
+	var data rotateProjectKeysActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	var args []string
+	resp.Diagnostics.Append(data.SecretKeyCmd.ElementsAs(ctx, &args, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	orgID, projID := data.OrganizationID.ValueString(), data.ProjectID.ValueString()
+
+	// List first, so that only keys older than the new one are revoked.
+	existing, err := a.client.ListProjectAPIKeys(ctx, orgID, projID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing project API keys", err)
+		return
+	}
+	key, err := a.client.CreateProjectAPIKey(ctx, orgID, projID, client.APIKeyCreate{Note: data.Note.ValueString()})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error creating project API key", err)
+		return
+	}
+	resp.Diagnostics.Append(deliverSecretKey(ctx, args, &client.Project{
+		ID:             projID,
+		OrganizationID: orgID,
+		PublicKey:      key.PublicKey,
+		SecretKey:      key.SecretKey,
+	})...)
+	if resp.Diagnostics.HasError() {
+		// Nobody received the secret key, so the new pair is useless.
+		if err := a.client.DeleteProjectAPIKey(ctx, orgID, projID, key.ID); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Error revoking API key %s after failed key delivery", key.PublicKey), err)
+		}
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Created and delivered API key %s for project %s.", key.PublicKey, projID),
+	})
+
+	if !data.RevokeExisting.IsNull() && !data.RevokeExisting.ValueBool() {
+		return
+	}
+	for _, old := range existing {
+		if err := a.client.DeleteProjectAPIKey(ctx, orgID, projID, old.ID); err != nil {
+			addClientError(&resp.Diagnostics, fmt.Sprintf("Error revoking API key %s", old.PublicKey), err)
+			continue
+		}
+		resp.SendProgress(action.InvokeProgressEvent{
+			Message: fmt.Sprintf("Revoked API key %s.", old.PublicKey),
+		})
+	}
+}
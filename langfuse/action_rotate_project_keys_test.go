@@ -0,0 +1,91 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRotateProjectKeysActionInvoke(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no shell to run the secret key command")
+	}
+	tests := []struct {
+		name           string
+		command        string
+		revokeExisting types.Bool
+		err            error
+		wantErr        string
+		wantOldKeys    int
+		wantNewKeys    int
+	}{
+		{name: "rotated", command: `cat > "$OUT"`, revokeExisting: types.BoolNull(), wantNewKeys: 1},
+		{name: "keep existing", command: `cat > "$OUT"`, revokeExisting: types.BoolValue(false), wantOldKeys: 2, wantNewKeys: 1},
+		{name: "delivery failed", command: "exit 3", revokeExisting: types.BoolNull(), wantErr: "Secret key command failed", wantOldKeys: 2},
+		{name: "api error", command: `cat > "$OUT"`, revokeExisting: types.BoolNull(), err: errors.New("connection refused"), wantErr: "Error listing project API keys", wantOldKeys: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+			// The project's own key and an additional one.
+			fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{Note: "ci"})
+			fake.Err = tt.err
+
+			out := filepath.Join(t.TempDir(), "secret")
+			t.Setenv("OUT", out)
+			command, diags := types.ListValueFrom(ctx, types.StringType, []string{"sh", "-c", tt.command})
+			requireNoErrors(t, diags)
+			a := &rotateProjectKeysAction{client: fake}
+			resp, progress := invokeAction(t, a, rotateProjectKeysActionModel{
+				OrganizationID: types.StringValue(org.ID),
+				ProjectID:      types.StringValue(proj.ID),
+				Note:           types.StringValue("rotated"),
+				SecretKeyCmd:   command,
+				RevokeExisting: tt.revokeExisting,
+			})
+			fake.Err = nil
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+			} else {
+				requireNoErrors(t, resp.Diagnostics)
+			}
+
+			keys, err := fake.ListProjectAPIKeys(ctx, org.ID, proj.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var old, rotated []client.APIKey
+			for _, k := range keys {
+				if k.Note == "rotated" {
+					rotated = append(rotated, k)
+				} else {
+					old = append(old, k)
+				}
+			}
+			if len(old) != tt.wantOldKeys || len(rotated) != tt.wantNewKeys {
+				t.Fatalf("%d previous and %d new keys exist, want %d and %d", len(old), len(rotated), tt.wantOldKeys, tt.wantNewKeys)
+			}
+			if tt.wantNewKeys == 0 {
+				return
+			}
+			secret, err := os.ReadFile(out)
+			if err != nil || !strings.HasPrefix(string(secret), "sk-lf-") || !strings.HasSuffix(rotated[0].DisplaySecretKey, string(secret[len(secret)-4:])) {
+				t.Errorf("command received %q (%v), want the secret key of %s", secret, err, rotated[0].PublicKey)
+			}
+			if wantProgress := 1 + 2 - len(old); len(progress) != wantProgress {
+				t.Errorf("got progress %q, want %d messages", progress, wantProgress)
+			}
+		})
+	}
+}
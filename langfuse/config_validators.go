@@ -0,0 +1,209 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+)
+
+// attributeSetRule is a configuration validator relating several top-level
+// attributes. It can be used by providers, resources and ephemeral resources.
+type attributeSetRule struct {
+	names []string
+	// together requires the attributes to be set together; otherwise at most
+	// one of them may be set.
+	together bool
+}
+
+var (
+	_ provider.ConfigValidator  = attributeSetRule{}
+	_ resource.ConfigValidator  = attributeSetRule{}
+	_ ephemeral.ConfigValidator = attributeSetRule{}
+)
+
+// conflicting returns a validator allowing at most one of the attributes.
+func conflicting(names ...string) attributeSetRule {
+	return attributeSetRule{names: names}
+}
+
+// requiredTogether returns a validator requiring the attributes to be either
+// all set or all unset.
+func requiredTogether(names ...string) attributeSetRule {
+	return attributeSetRule{names: names, together: true}
+}
+
+// Description implements the Describer part of the config validator interfaces.
+func (v attributeSetRule) Description(ctx context.Context) string {
+	if v.together {
+		return "attributes " + v.list() + " must be set together"
+	}
+	return "at most one of " + v.list() + " may be set"
+}
+
+// MarkdownDescription implements the Describer part of the config validator interfaces.
+func (v attributeSetRule) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateProvider implements provider.ConfigValidator.
+func (v attributeSetRule) ValidateProvider(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// ValidateResource implements resource.ConfigValidator.
+func (v attributeSetRule) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// ValidateEphemeralResource implements ephemeral.ConfigValidator.
+func (v attributeSetRule) ValidateEphemeralResource(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// validate checks the rule against config. Unknown values are skipped, as
+// they may still turn out either way.
+func (v attributeSetRule) validate(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var set, unset []string
+	for _, name := range v.names {
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() {
+			return diags
+		}
+		switch {
+		case value.IsUnknown():
+			return diags
+		case value.IsNull():
+			unset = append(unset, name)
+		default:
+			set = append(set, name)
+		}
+	}
+
+	switch {
+	case v.together && len(set) > 0 && len(unset) > 0:
+		diags.AddAttributeError(
+			path.Root(unset[0]),
+			"Missing attribute configuration",
+			fmt.Sprintf("%s must be set together; %s is set but %s is not.", v.list(), quoteAll(set), quoteAll(unset)),
+		)
+	case !v.together && len(set) > 1:
+		diags.AddAttributeError(
+			path.Root(set[1]),
+			"Conflicting attribute configuration",
+			fmt.Sprintf("Only one of %s may be set, got %s.", v.list(), quoteAll(set)),
+		)
+	}
+	return diags
+}
+
+// list formats the attribute names for messages.
+func (v attributeSetRule) list() string {
+	return quoteAll(v.names)
+}
+
+// attributeValueRule is a configuration validator requiring attributes to be
+// set while another attribute has a given value.
+type attributeValueRule struct {
+	trigger string
+	value   attr.Value
+	names   []string
+	// reason explains the rule in messages; warn reports a violation as a
+	// warning rather than an error.
+	reason string
+	warn   bool
+}
+
+var (
+	_ resource.ConfigValidator  = attributeValueRule{}
+	_ ephemeral.ConfigValidator = attributeValueRule{}
+)
+
+// requiredWhen returns a validator requiring the attributes to be set while
+// trigger is configured to value.
+func requiredWhen(trigger string, value attr.Value, names ...string) attributeValueRule {
+	return attributeValueRule{trigger: trigger, value: value, names: names}
+}
+
+// asWarning returns a copy of the rule reporting violations as warnings, with
+// reason added to their message.
+func (v attributeValueRule) asWarning(reason string) attributeValueRule {
+	v.warn = true
+	v.reason = reason
+	return v
+}
+
+// Description implements the Describer part of the config validator interfaces.
+func (v attributeValueRule) Description(ctx context.Context) string {
+	return "attributes " + quoteAll(v.names) + " must be set when `" + v.trigger + "` is " + v.value.String()
+}
+
+// MarkdownDescription implements the Describer part of the config validator interfaces.
+func (v attributeValueRule) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements resource.ConfigValidator.
+func (v attributeValueRule) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// ValidateEphemeralResource implements ephemeral.ConfigValidator.
+func (v attributeValueRule) ValidateEphemeralResource(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	resp.Diagnostics.Append(v.validate(ctx, req.Config)...)
+}
+
+// validate checks the rule against config. Unknown values are skipped, as
+// they may still turn out either way.
+func (v attributeValueRule) validate(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var trigger attr.Value
+	diags.Append(config.GetAttribute(ctx, path.Root(v.trigger), &trigger)...)
+	if diags.HasError() || trigger.IsUnknown() || !trigger.Equal(v.value) {
+		return diags
+	}
+
+	var unset []string
+	for _, name := range v.names {
+		var value attr.Value
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+		if diags.HasError() || value.IsUnknown() {
+			return diags
+		}
+		if value.IsNull() {
+			unset = append(unset, name)
+		}
+	}
+	if len(unset) == 0 {
+		return diags
+	}
+
+	detail := fmt.Sprintf("`%s` is %s but %s is not set.", v.trigger, v.value, quoteAll(unset))
+	if v.reason != "" {
+		detail += " " + v.reason
+	}
+	if v.warn {
+		diags.AddAttributeWarning(path.Root(v.trigger), "Incomplete attribute configuration", detail)
+	} else {
+		diags.AddAttributeError(path.Root(unset[0]), "Missing attribute configuration", detail)
+	}
+	return diags
+}
+
+// quoteAll formats attribute names as a comma-separated list of code spans.
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = "`" + n + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
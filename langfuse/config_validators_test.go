@@ -0,0 +1,124 @@
+package langfuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAttributeRules(t *testing.T) {
+	s := resourceSchema(t, NewProjectResource())
+	config := func(edit func(m *projectResourceModel)) tfsdk.Config {
+		m := projectModel("org-1", "", "team")
+		m.StoreSecretKey = types.BoolNull()
+		edit(&m)
+		return tfsdk.Config{Schema: s, Raw: newState(t, s, &m).Raw}
+	}
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("true")})
+
+	tests := []struct {
+		name   string
+		rule   resource.ConfigValidator
+		edit   func(m *projectResourceModel)
+		errors int
+		warns  int
+	}{
+		{
+			name: "conflicting unset",
+			rule: conflicting("duplicate_name_check", "ignore_name_case"),
+			edit: func(m *projectResourceModel) {},
+		},
+		{
+			name: "conflicting one set",
+			rule: conflicting("duplicate_name_check", "ignore_name_case"),
+			edit: func(m *projectResourceModel) { m.DuplicateCheck = types.StringValue("warn") },
+		},
+		{
+			name: "conflicting both set",
+			rule: conflicting("duplicate_name_check", "ignore_name_case"),
+			edit: func(m *projectResourceModel) {
+				m.DuplicateCheck = types.StringValue("warn")
+				m.IgnoreCase = types.BoolValue(true)
+			},
+			errors: 1,
+		},
+		{
+			name: "conflicting unknown",
+			rule: conflicting("duplicate_name_check", "ignore_name_case"),
+			edit: func(m *projectResourceModel) {
+				m.DuplicateCheck = types.StringValue("warn")
+				m.IgnoreCase = types.BoolUnknown()
+			},
+		},
+		{
+			name: "together all set",
+			rule: requiredTogether("duplicate_name_check", "ignore_name_case"),
+			edit: func(m *projectResourceModel) {
+				m.DuplicateCheck = types.StringValue("warn")
+				m.IgnoreCase = types.BoolValue(true)
+			},
+		},
+		{
+			name:   "together one set",
+			rule:   requiredTogether("duplicate_name_check", "ignore_name_case"),
+			edit:   func(m *projectResourceModel) { m.IgnoreCase = types.BoolValue(true) },
+			errors: 1,
+		},
+		{
+			name: "required when other value",
+			rule: requiredWhen("store_secret_key", types.BoolValue(false), "secret_key_command"),
+			edit: func(m *projectResourceModel) { m.StoreSecretKey = types.BoolValue(true) },
+		},
+		{
+			name:   "required when missing",
+			rule:   requiredWhen("store_secret_key", types.BoolValue(false), "secret_key_command"),
+			edit:   func(m *projectResourceModel) { m.StoreSecretKey = types.BoolValue(false) },
+			errors: 1,
+		},
+		{
+			name: "required when set",
+			rule: requiredWhen("store_secret_key", types.BoolValue(false), "secret_key_command"),
+			edit: func(m *projectResourceModel) { m.StoreSecretKey = types.BoolValue(false); m.SecretKeyCmd = command },
+		},
+		{
+			name:  "required when as warning",
+			rule:  requiredWhen("store_secret_key", types.BoolValue(false), "secret_key_command").asWarning("Because."),
+			edit:  func(m *projectResourceModel) { m.StoreSecretKey = types.BoolValue(false) },
+			warns: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := resource.ValidateConfigRequest{Config: config(tt.edit)}
+			var resp resource.ValidateConfigResponse
+			tt.rule.ValidateResource(context.Background(), req, &resp)
+			if got := resp.Diagnostics.ErrorsCount(); got != tt.errors {
+				t.Errorf("got %d errors, want %d: %v", got, tt.errors, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tt.warns {
+				t.Errorf("got %d warnings, want %d: %v", got, tt.warns, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProjectResourceConfigValidators(t *testing.T) {
+	r := NewProjectResource().(*projectResource)
+	s := resourceSchema(t, r)
+	m := projectModel("org-1", "", "team")
+	m.StoreSecretKey = types.BoolValue(false)
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: newState(t, s, &m).Raw}}
+
+	var resp resource.ValidateConfigResponse
+	for _, v := range r.ConfigValidators(context.Background()) {
+		v.ValidateResource(context.Background(), req, &resp)
+	}
+	requireNoErrors(t, resp.Diagnostics)
+	if len(resp.Diagnostics.Warnings()) != 1 {
+		t.Fatalf("expected a warning about the discarded secret key, got %v", resp.Diagnostics)
+	}
+}
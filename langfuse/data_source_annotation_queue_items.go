@@ -0,0 +1,162 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// annotationQueueItemsDataSource implements the langfuse_annotation_queue_items data source.
+type annotationQueueItemsDataSource struct {
+	client client.LangfuseAPI
+}
+
+// NewAnnotationQueueItemsDataSource returns a new annotationQueueItemsDataSource.
+func NewAnnotationQueueItemsDataSource() datasource.DataSource {
+	return &annotationQueueItemsDataSource{}
+}
+
+var _ datasource.DataSourceWithConfigure = &annotationQueueItemsDataSource{}
+
+// Metadata sets the data source type name.
+func (d *annotationQueueItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_annotation_queue_items"
+}
+
+// Schema defines the langfuse_annotation_queue_items data source schema.
+func (d *annotationQueueItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	statuses := client.EnumStrings(client.AnnotationQueueStatuses())
+	resp.Schema = schema.Schema{
+		Description: "Lists the items of a Langfuse annotation queue, e.g. to track the review backlog of a project on a dashboard. Requires `public_key` and `secret_key` of the project owning the queue in the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"queue_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the annotation queue.",
+			},
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{stringOneOf(statuses...)},
+				Description: "Only list items with this status, `PENDING` or `COMPLETED`. All items are listed when unset.",
+			},
+			"pending_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of listed items awaiting review.",
+			},
+			"completed_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of listed items that have been reviewed.",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Items of the queue, in the order returned by Langfuse.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the queue item.",
+						},
+						"object_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the trace, observation or session to review.",
+						},
+						"object_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Type of the object to review: `TRACE`, `OBSERVATION` or `SESSION`.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Review status, `PENDING` or `COMPLETED`.",
+						},
+						"completed_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the review was completed, in RFC 3339 format. Null for pending items.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the item was added to the queue, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// annotationQueueItemsDataSourceModel maps the langfuse_annotation_queue_items data source schema.
+type annotationQueueItemsDataSourceModel struct {
+	QueueID        types.String               `tfsdk:"queue_id"`
+	Status         types.String               `tfsdk:"status"`
+	PendingCount   types.Int64                `tfsdk:"pending_count"`
+	CompletedCount types.Int64                `tfsdk:"completed_count"`
+	Items          []annotationQueueItemModel `tfsdk:"items"`
+}
+
+// annotationQueueItemModel maps an element of the items attribute.
+type annotationQueueItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	ObjectID    types.String `tfsdk:"object_id"`
+	ObjectType  types.String `tfsdk:"object_type"`
+	Status      types.String `tfsdk:"status"`
+	CompletedAt types.String `tfsdk:"completed_at"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// Configure injects the Langfuse client.
+func (d *annotationQueueItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = clientData
+}
+
+// Read lists the items of the queue.
+func (d *annotationQueueItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer logClientMetrics(ctx, d.client)
+
+	var data annotationQueueItemsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	status := client.AnnotationQueueStatus(data.Status.ValueString())
+	items, err := d.client.ListAnnotationQueueItems(ctx, data.QueueID.ValueString(), status)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing annotation queue items", err)
+		return
+	}
+
+	var pending, completed int64
+	data.Items = make([]annotationQueueItemModel, 0, len(items))
+	for _, item := range items {
+		switch item.Status {
+		case client.AnnotationQueueStatusPending:
+			pending++
+		case client.AnnotationQueueStatusCompleted:
+			completed++
+		}
+		data.Items = append(data.Items, annotationQueueItemModel{
+			ID:          types.StringValue(item.ID),
+			ObjectID:    types.StringValue(item.ObjectID),
+			ObjectType:  types.StringValue(string(item.ObjectType)),
+			Status:      types.StringValue(string(item.Status)),
+			CompletedAt: optionalString(item.CompletedAt),
+			CreatedAt:   types.StringValue(item.CreatedAt),
+		})
+	}
+	data.PendingCount = types.Int64Value(pending)
+	data.CompletedCount = types.Int64Value(completed)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
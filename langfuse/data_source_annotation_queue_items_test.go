@@ -0,0 +1,111 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAnnotationQueueItemsDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		queueID       string
+		status        string
+		err           error
+		wantItems     int
+		wantPending   int64
+		wantCompleted int64
+		wantErr       string
+	}{
+		{name: "all items", queueID: "queue-1", wantItems: 3, wantPending: 2, wantCompleted: 1},
+		{name: "pending items", queueID: "queue-1", status: "PENDING", wantItems: 2, wantPending: 2},
+		{name: "missing queue", queueID: "queue-2", wantErr: "Error listing annotation queue items"},
+		{name: "api error", queueID: "queue-1", err: errors.New("connection reset"), wantErr: "Error listing annotation queue items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			for _, item := range []client.AnnotationQueueItem{
+				{ID: "item-1", QueueID: "queue-1", ObjectID: "trace-1", ObjectType: "TRACE", Status: client.AnnotationQueueStatusPending},
+				{ID: "item-2", QueueID: "queue-1", ObjectID: "obs-1", ObjectType: "OBSERVATION", Status: client.AnnotationQueueStatusCompleted, CompletedAt: "2025-01-02T00:00:00.000Z"},
+				{ID: "item-3", QueueID: "queue-1", ObjectID: "session-1", ObjectType: "SESSION", Status: client.AnnotationQueueStatusPending},
+			} {
+				fake.AddAnnotationQueueItem(item)
+			}
+			fake.Err = tt.err
+			d := &annotationQueueItemsDataSource{client: fake}
+			status := types.StringNull()
+			if tt.status != "" {
+				status = types.StringValue(tt.status)
+			}
+			resp := readDataSource(t, d, &annotationQueueItemsDataSourceModel{
+				QueueID:        types.StringValue(tt.queueID),
+				Status:         status,
+				PendingCount:   types.Int64Null(),
+				CompletedCount: types.Int64Null(),
+			})
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got annotationQueueItemsDataSourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if len(got.Items) != tt.wantItems {
+				t.Fatalf("got %d items, want %d", len(got.Items), tt.wantItems)
+			}
+			if got.PendingCount.ValueInt64() != tt.wantPending || got.CompletedCount.ValueInt64() != tt.wantCompleted {
+				t.Errorf("pending_count, completed_count = %s, %s; want %d, %d", got.PendingCount, got.CompletedCount, tt.wantPending, tt.wantCompleted)
+			}
+			if !got.Items[0].CompletedAt.IsNull() {
+				t.Errorf("completed_at of a pending item = %s, want null", got.Items[0].CompletedAt)
+			}
+		})
+	}
+}
+
+func TestAnnotationQueueItemsDataSourceStatusValidation(t *testing.T) {
+	ctx := context.Background()
+	var resp datasource.SchemaResponse
+	(&annotationQueueItemsDataSource{}).Schema(ctx, datasource.SchemaRequest{}, &resp)
+	status := resp.Schema.Attributes["status"].(schema.StringAttribute)
+
+	tests := []struct {
+		value   string
+		wantErr string
+	}{
+		{value: "PENDING"},
+		{value: "COMPLETED"},
+		{value: "PENDNG", wantErr: `must be one of "PENDING", "COMPLETED", got "PENDNG"`},
+		{value: "pending", wantErr: `Did you mean "PENDING"?`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var diags diag.Diagnostics
+			for _, v := range status.Validators {
+				var vresp validator.StringResponse
+				v.ValidateString(ctx, validator.StringRequest{Path: path.Root("status"), ConfigValue: types.StringValue(tt.value)}, &vresp)
+				diags.Append(vresp.Diagnostics...)
+			}
+			if tt.wantErr == "" {
+				requireNoErrors(t, diags)
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags[0].Detail(), tt.wantErr) {
+				t.Errorf("got %v, want an error containing %q", diags, tt.wantErr)
+			}
+		})
+	}
+}
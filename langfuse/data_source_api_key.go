@@ -0,0 +1,145 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiKeyDataSource implements the langfuse_api_key data source.
+type apiKeyDataSource struct {
+	client client.LangfuseAPI
+}
+
+// NewAPIKeyDataSource returns a new apiKeyDataSource.
+func NewAPIKeyDataSource() datasource.DataSource {
+	return &apiKeyDataSource{}
+}
+
+var _ datasource.DataSourceWithConfigure = &apiKeyDataSource{}
+
+// Metadata sets the data source type name.
+func (d *apiKeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+// Schema defines the langfuse_api_key data source schema.
+func (d *apiKeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up the metadata of a project API key by its public key, e.g. to check whether a credential is still known to Langfuse before rotating it. Unknown keys do not fail the lookup; check `exists` instead.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the organization owning the project.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project the key belongs to.",
+			},
+			"public_key": schema.StringAttribute{
+				Required:    true,
+				Description: "Public key to look up, `pk-lf-...`.",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the project has an API key with this public key. The remaining attributes are null when it does not.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the API key.",
+			},
+			"display_secret_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "Shortened form of the secret key, e.g. `sk-lf-...abcd`.",
+			},
+			"note": schema.StringAttribute{
+				Computed:    true,
+				Description: "Note attached to the key.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the key was created, in RFC 3339 format.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the key expires, in RFC 3339 format. Null for keys that do not expire.",
+			},
+			"last_used_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the key was last used, in RFC 3339 format. Null for keys that have never been used.",
+			},
+		},
+	}
+}
+
+// apiKeyDataSourceModel maps the langfuse_api_key data source schema.
+type apiKeyDataSourceModel struct {
+	OrganizationID   types.String `tfsdk:"organization_id"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	Exists           types.Bool   `tfsdk:"exists"`
+	ID               types.String `tfsdk:"id"`
+	DisplaySecretKey types.String `tfsdk:"display_secret_key"`
+	Note             types.String `tfsdk:"note"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	LastUsedAt       types.String `tfsdk:"last_used_at"`
+}
+
+// Configure injects the Langfuse client.
+func (d *apiKeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = clientData
+}
+
+// Read looks the key up among the API keys of the project.
+func (d *apiKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer logClientMetrics(ctx, d.client)
+
+	var data apiKeyDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys, err := d.client.ListProjectAPIKeys(ctx, data.OrganizationID.ValueString(), data.ProjectID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing project API keys", err)
+		return
+	}
+
+	data.Exists = types.BoolValue(false)
+	data.ID = types.StringNull()
+	data.DisplaySecretKey = types.StringNull()
+	data.Note = types.StringNull()
+	data.CreatedAt = types.StringNull()
+	data.ExpiresAt = types.StringNull()
+	data.LastUsedAt = types.StringNull()
+	for _, key := range keys {
+		if key.PublicKey != data.PublicKey.ValueString() {
+			continue
+		}
+		data.Exists = types.BoolValue(true)
+		data.ID = types.StringValue(key.ID)
+		data.DisplaySecretKey = optionalString(key.DisplaySecretKey)
+		data.Note = optionalString(key.Note)
+		data.CreatedAt = optionalString(key.CreatedAt)
+		data.ExpiresAt = optionalString(key.ExpiresAt)
+		data.LastUsedAt = optionalString(key.LastUsedAt)
+		break
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,71 @@
+package langfuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAPIKeyDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	key, _ := fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{Note: "checkout service"})
+	fake.SetAPIKeyExpiry(proj.ID, key.ID, "2030-01-01T00:00:00Z")
+
+	tests := []struct {
+		name       string
+		projectID  string
+		publicKey  string
+		wantExists bool
+		wantErr    string
+	}{
+		{name: "known key", projectID: proj.ID, publicKey: key.PublicKey, wantExists: true},
+		{name: "unknown key", projectID: proj.ID, publicKey: "pk-lf-rotated"},
+		{name: "missing project", projectID: "proj-missing", publicKey: proj.PublicKey, wantErr: "Error listing project API keys"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &apiKeyDataSource{client: fake}
+			resp := readDataSource(t, d, &apiKeyDataSourceModel{
+				OrganizationID:   types.StringValue(org.ID),
+				ProjectID:        types.StringValue(tt.projectID),
+				PublicKey:        types.StringValue(tt.publicKey),
+				Exists:           types.BoolNull(),
+				ID:               types.StringNull(),
+				DisplaySecretKey: types.StringNull(),
+				Note:             types.StringNull(),
+				CreatedAt:        types.StringNull(),
+				ExpiresAt:        types.StringNull(),
+				LastUsedAt:       types.StringNull(),
+			})
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got apiKeyDataSourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if got.Exists.ValueBool() != tt.wantExists {
+				t.Errorf("exists = %s, want %t", got.Exists, tt.wantExists)
+			}
+			if got.ID.IsNull() == tt.wantExists || got.DisplaySecretKey.IsNull() == tt.wantExists {
+				t.Errorf("id, display_secret_key = %s, %s; want them set only for known keys", got.ID, got.DisplaySecretKey)
+			}
+			if !tt.wantExists {
+				if !got.Note.IsNull() || !got.CreatedAt.IsNull() || !got.ExpiresAt.IsNull() {
+					t.Errorf("note, created_at, expires_at = %s, %s, %s; want null", got.Note, got.CreatedAt, got.ExpiresAt)
+				}
+				return
+			}
+			if got.Note.ValueString() != key.Note || got.CreatedAt.ValueString() != key.CreatedAt || got.ExpiresAt.ValueString() != "2030-01-01T00:00:00Z" {
+				t.Errorf("note, created_at, expires_at = %s, %s, %s; want %q, %s, 2030-01-01T00:00:00Z", got.Note, got.CreatedAt, got.ExpiresAt, key.Note, key.CreatedAt)
+			}
+		})
+	}
+}
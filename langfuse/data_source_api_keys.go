@@ -0,0 +1,145 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiKeysDataSource implements the langfuse_api_keys data source.
+type apiKeysDataSource struct {
+	client client.LangfuseAPI
+}
+
+// NewAPIKeysDataSource returns a new apiKeysDataSource.
+func NewAPIKeysDataSource() datasource.DataSource {
+	return &apiKeysDataSource{}
+}
+
+var _ datasource.DataSourceWithConfigure = &apiKeysDataSource{}
+
+// Metadata sets the data source type name.
+func (d *apiKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_keys"
+}
+
+// Schema defines the langfuse_api_keys data source schema.
+func (d *apiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the API keys of a Langfuse project along with their notes, e.g. to audit which services hold credentials for a project.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the organization owning the project.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project.",
+			},
+			"keys": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "API keys of the project, in the order returned by Langfuse.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the API key.",
+						},
+						"public_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "Public key, `pk-lf-...`.",
+						},
+						"display_secret_key": schema.StringAttribute{
+							Computed:    true,
+							Description: "Shortened form of the secret key, e.g. `sk-lf-...abcd`.",
+						},
+						"note": schema.StringAttribute{
+							Computed:    true,
+							Description: "Note attached to the key. Null for keys without a note.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the key was created, in RFC 3339 format.",
+						},
+						"expires_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the key expires, in RFC 3339 format. Null for keys that do not expire.",
+						},
+						"last_used_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the key was last used, in RFC 3339 format. Null for keys that have never been used.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// apiKeysDataSourceModel maps the langfuse_api_keys data source schema.
+type apiKeysDataSourceModel struct {
+	OrganizationID types.String      `tfsdk:"organization_id"`
+	ProjectID      types.String      `tfsdk:"project_id"`
+	Keys           []apiKeyItemModel `tfsdk:"keys"`
+}
+
+// apiKeyItemModel maps an element of the keys attribute.
+type apiKeyItemModel struct {
+	ID               types.String `tfsdk:"id"`
+	PublicKey        types.String `tfsdk:"public_key"`
+	DisplaySecretKey types.String `tfsdk:"display_secret_key"`
+	Note             types.String `tfsdk:"note"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	LastUsedAt       types.String `tfsdk:"last_used_at"`
+}
+
+// Configure injects the Langfuse client.
+func (d *apiKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = clientData
+}
+
+// Read lists the API keys of the project.
+func (d *apiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer logClientMetrics(ctx, d.client)
+
+	var data apiKeysDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys, err := d.client.ListProjectAPIKeys(ctx, data.OrganizationID.ValueString(), data.ProjectID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error listing project API keys", err)
+		return
+	}
+
+	data.Keys = make([]apiKeyItemModel, 0, len(keys))
+	for _, key := range keys {
+		data.Keys = append(data.Keys, apiKeyItemModel{
+			ID:               types.StringValue(key.ID),
+			PublicKey:        types.StringValue(key.PublicKey),
+			DisplaySecretKey: optionalString(key.DisplaySecretKey),
+			Note:             optionalString(key.Note),
+			CreatedAt:        optionalString(key.CreatedAt),
+			ExpiresAt:        optionalString(key.ExpiresAt),
+			LastUsedAt:       optionalString(key.LastUsedAt),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
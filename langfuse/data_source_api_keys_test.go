@@ -0,0 +1,53 @@
+package langfuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAPIKeysDataSourceRead(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	key, _ := fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{Note: "checkout service, production"})
+
+	tests := []struct {
+		name      string
+		projectID string
+		wantErr   string
+	}{
+		{name: "success", projectID: proj.ID},
+		{name: "missing project", projectID: "proj-missing", wantErr: "Error listing project API keys"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &apiKeysDataSource{client: fake}
+			resp := readDataSource(t, d, &apiKeysDataSourceModel{
+				OrganizationID: types.StringValue(org.ID),
+				ProjectID:      types.StringValue(tt.projectID),
+			})
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got apiKeysDataSourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if len(got.Keys) != 2 {
+				t.Fatalf("got %d keys, want 2", len(got.Keys))
+			}
+			if got.Keys[0].PublicKey.ValueString() != proj.PublicKey || !got.Keys[0].Note.IsNull() {
+				t.Errorf("initial key = %+v, want %s without note", got.Keys[0], proj.PublicKey)
+			}
+			if got.Keys[1].ID.ValueString() != key.ID || got.Keys[1].Note.ValueString() != key.Note {
+				t.Errorf("second key = %+v, want %s with note %q", got.Keys[1], key.ID, key.Note)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// datasetRunItemsDataSource implements the langfuse_dataset_run_items data source.
+type datasetRunItemsDataSource struct {
+	client client.LangfuseAPI
+}
+
+// NewDatasetRunItemsDataSource returns a new datasetRunItemsDataSource.
+func NewDatasetRunItemsDataSource() datasource.DataSource {
+	return &datasetRunItemsDataSource{}
+}
+
+var _ datasource.DataSourceWithConfigure = &datasetRunItemsDataSource{}
+
+// Metadata sets the data source type name.
+func (d *datasetRunItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataset_run_items"
+}
+
+// Schema defines the langfuse_dataset_run_items data source schema.
+func (d *datasetRunItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a run of a Langfuse dataset along with its items, e.g. to inspect the traces of an evaluation run in a deployment pipeline. Requires `public_key` and `secret_key` of the project owning the dataset in the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"dataset_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the dataset.",
+			},
+			"run_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the dataset run.",
+			},
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the dataset run.",
+			},
+			"dataset_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the dataset.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "Description of the run.",
+			},
+			"metadata": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON-encoded metadata of the run.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the run was created, in RFC 3339 format.",
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Items of the run, one per processed dataset item.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the run item.",
+						},
+						"dataset_item_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the processed dataset item.",
+						},
+						"trace_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the trace holding the output and scores of the item.",
+						},
+						"observation_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the observation within the trace, if the item is linked to one.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:    true,
+							Description: "Time the item was run, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// datasetRunItemsDataSourceModel maps the langfuse_dataset_run_items data source schema.
+type datasetRunItemsDataSourceModel struct {
+	DatasetName types.String          `tfsdk:"dataset_name"`
+	RunName     types.String          `tfsdk:"run_name"`
+	ID          types.String          `tfsdk:"id"`
+	DatasetID   types.String          `tfsdk:"dataset_id"`
+	Description types.String          `tfsdk:"description"`
+	Metadata    types.String          `tfsdk:"metadata"`
+	CreatedAt   types.String          `tfsdk:"created_at"`
+	Items       []datasetRunItemModel `tfsdk:"items"`
+}
+
+// datasetRunItemModel maps an element of the items attribute.
+type datasetRunItemModel struct {
+	ID            types.String `tfsdk:"id"`
+	DatasetItemID types.String `tfsdk:"dataset_item_id"`
+	TraceID       types.String `tfsdk:"trace_id"`
+	ObservationID types.String `tfsdk:"observation_id"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+}
+
+// Configure injects the Langfuse client.
+func (d *datasetRunItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	d.client = clientData
+}
+
+// Read fetches the dataset run and its items.
+func (d *datasetRunItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer logClientMetrics(ctx, d.client)
+
+	var data datasetRunItemsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	run, err := d.client.GetDatasetRun(ctx, data.DatasetName.ValueString(), data.RunName.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error reading dataset run", err)
+		return
+	}
+
+	// Decoding and encoding again normalizes the JSON, e.g. sorts its keys.
+	var fields map[string]any
+	if len(run.Metadata) > 0 {
+		if err := json.Unmarshal(run.Metadata, &fields); err != nil {
+			resp.Diagnostics.AddError("Error decoding dataset run metadata", err.Error())
+			return
+		}
+	}
+	metadata := "{}"
+	if len(fields) > 0 {
+		b, err := json.Marshal(fields)
+		if err != nil {
+			resp.Diagnostics.AddError("Error encoding dataset run metadata", err.Error())
+			return
+		}
+		metadata = string(b)
+	}
+
+	data.ID = types.StringValue(run.ID)
+	data.DatasetID = types.StringValue(run.DatasetID)
+	data.Description = optionalString(run.Description)
+	data.Metadata = types.StringValue(metadata)
+	data.CreatedAt = types.StringValue(run.CreatedAt)
+	data.Items = make([]datasetRunItemModel, 0, len(run.DatasetRunItems))
+	for _, item := range run.DatasetRunItems {
+		data.Items = append(data.Items, datasetRunItemModel{
+			ID:            types.StringValue(item.ID),
+			DatasetItemID: types.StringValue(item.DatasetItemID),
+			TraceID:       types.StringValue(item.TraceID),
+			ObservationID: optionalString(item.ObservationID),
+			CreatedAt:     types.StringValue(item.CreatedAt),
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// optionalString returns s as a string value, or null if it is empty.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,80 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDatasetRunItemsDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		run     string
+		err     error
+		wantErr string
+	}{
+		{name: "success", run: "nightly"},
+		{name: "missing run", run: "weekly", wantErr: "Error reading dataset run"},
+		{name: "api error", run: "nightly", err: errors.New("connection reset"), wantErr: "Error reading dataset run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			fake.AddDatasetRun(client.DatasetRun{
+				ID:          "run-1",
+				Name:        "nightly",
+				DatasetID:   "dataset-1",
+				DatasetName: "qa",
+				Metadata:    json.RawMessage(`{ "commit": "abc123" }`),
+				CreatedAt:   "2025-01-01T00:00:00.000Z",
+				DatasetRunItems: []client.DatasetRunItem{
+					{ID: "item-run-1", DatasetItemID: "item-1", TraceID: "trace-1", CreatedAt: "2025-01-01T00:00:00.000Z"},
+					{ID: "item-run-2", DatasetItemID: "item-2", TraceID: "trace-2", ObservationID: "obs-2", CreatedAt: "2025-01-01T00:00:01.000Z"},
+				},
+			})
+			fake.Err = tt.err
+			d := &datasetRunItemsDataSource{client: fake}
+			resp := readDataSource(t, d, &datasetRunItemsDataSourceModel{
+				DatasetName: types.StringValue("qa"),
+				RunName:     types.StringValue(tt.run),
+				ID:          types.StringNull(),
+				DatasetID:   types.StringNull(),
+				Description: types.StringNull(),
+				Metadata:    types.StringNull(),
+				CreatedAt:   types.StringNull(),
+			})
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got datasetRunItemsDataSourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if got.ID.ValueString() != "run-1" || got.DatasetID.ValueString() != "dataset-1" {
+				t.Errorf("id, dataset_id = %s, %s; want run-1, dataset-1", got.ID, got.DatasetID)
+			}
+			if got.Metadata.ValueString() != `{"commit":"abc123"}` {
+				t.Errorf("metadata = %s", got.Metadata)
+			}
+			if !got.Description.IsNull() {
+				t.Errorf("description = %s, want null", got.Description)
+			}
+			if len(got.Items) != 2 {
+				t.Fatalf("got %d items, want 2", len(got.Items))
+			}
+			if got.Items[0].TraceID.ValueString() != "trace-1" || !got.Items[0].ObservationID.IsNull() {
+				t.Errorf("first item = %+v", got.Items[0])
+			}
+			if got.Items[1].ObservationID.ValueString() != "obs-2" {
+				t.Errorf("observation_id of second item = %s, want obs-2", got.Items[1].ObservationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package langfuse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+)
+
+// addClientError adds a diagnostic for an error returned by the Langfuse
+// client. Common API failures get a specific summary and a remediation hint;
+// other errors are reported as-is under summary. Credentials echoed back by
+// the API are masked, as diagnostics tend to end up in CI logs.
+func addClientError(diags *diag.Diagnostics, summary string, err error) {
+	summary, detail := describeClientError(summary, err)
+	addError(diags, summary, detail)
+}
+
+// addError adds an error diagnostic with credentials masked in its detail.
+func addError(diags *diag.Diagnostics, summary, detail string) {
+	diags.AddError(summary, client.RedactSecrets(detail))
+}
+
+// describeClientError returns the diagnostic summary and detail for err. The
+// summary is extended with the kind of failure where it is known.
+func describeClientError(summary string, err error) (string, string) {
+	var unsupported *client.UnsupportedError
+	if errors.As(err, &unsupported) {
+		summary += ": unsupported Langfuse version"
+		return summary, unsupported.Error() + "\n\nUpgrade the Langfuse instance to use this resource."
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return summary, err.Error()
+	}
+
+	hint := ""
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized:
+		summary += ": invalid API key"
+		hint = "Langfuse rejected the credentials. Check that the Admin API key configured for the provider matches the ADMIN_API_KEY of the instance."
+	case apiErr.StatusCode == http.StatusForbidden:
+		summary += ": permission denied"
+		hint = "The API key is valid but not allowed to perform this operation. Admin endpoints require an instance with the admin API enabled and, depending on the feature, an enterprise entitlement."
+	case apiErr.StatusCode == http.StatusNotFound:
+		summary += ": not found"
+		hint = "The object does not exist (anymore), or base_url does not point at the Langfuse API."
+	case apiErr.StatusCode == http.StatusConflict:
+		summary += ": conflict"
+		hint = "An object with the same identity already exists. Use a different name or import the existing object into Terraform state."
+	case apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusUnprocessableEntity:
+		summary += ": invalid request"
+		hint = "Langfuse rejected the request. Check the configured attribute values against the details above."
+	case apiErr.StatusCode == http.StatusTooManyRequests:
+		summary += ": rate limited"
+		hint = "The instance is rate limiting requests. Lower max_concurrent_requests or Terraform's -parallelism, or raise max_retries."
+	case apiErr.StatusCode >= 500:
+		summary += ": server error"
+		hint = "Langfuse failed to process the request. Retry the operation and check the server logs if the problem persists."
+	}
+
+	detail := apiErr.Error()
+	if apiErr.Code != "" {
+		detail += fmt.Sprintf("\n\nError code: %s", apiErr.Code)
+	}
+	if hint != "" {
+		detail += "\n\n" + hint
+	}
+	if apiErr.RequestID != "" {
+		detail += fmt.Sprintf("\n\nRequest ID: %s (include it when reporting the failure so the instance operator can find the matching server log entry)", apiErr.RequestID)
+	}
+	return summary, detail
+}
@@ -0,0 +1,155 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promptEphemeralResource implements the langfuse_prompt ephemeral resource.
+type promptEphemeralResource struct {
+	client client.LangfuseAPI
+}
+
+// NewPromptEphemeralResource returns a new promptEphemeralResource.
+func NewPromptEphemeralResource() ephemeral.EphemeralResource {
+	return &promptEphemeralResource{}
+}
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure        = &promptEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigValidators = &promptEphemeralResource{}
+)
+
+// Metadata sets the ephemeral resource type name.
+func (r *promptEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prompt"
+}
+
+// Schema defines the langfuse_prompt ephemeral resource schema.
+func (r *promptEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Fetches a prompt from Langfuse prompt management at apply time without storing its content in state or plan, e.g. to inject it into a Kubernetes secret. Requires `public_key` and `secret_key` of the project owning the prompt in the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the prompt.",
+			},
+			"label": schema.StringAttribute{
+				Optional:    true,
+				Description: "Label of the version to fetch. Conflicts with `version`. When neither is set, the version labeled `production` is fetched.",
+			},
+			"version": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Version to fetch. Conflicts with `label`. Set to the fetched version otherwise.",
+			},
+			"type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Prompt type, `text` or `chat`.",
+			},
+			"prompt": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Prompt content. For chat prompts, the JSON-encoded list of messages.",
+			},
+			"config": schema.StringAttribute{
+				Computed:    true,
+				Description: "JSON-encoded model configuration stored with the prompt.",
+			},
+			"labels": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Labels of the fetched version.",
+			},
+			"tags": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Tags of the prompt.",
+			},
+		},
+	}
+}
+
+// promptEphemeralResourceModel maps the langfuse_prompt ephemeral resource schema.
+type promptEphemeralResourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Label   types.String `tfsdk:"label"`
+	Version types.Int64  `tfsdk:"version"`
+	Type    types.String `tfsdk:"type"`
+	Prompt  types.String `tfsdk:"prompt"`
+	Config  types.String `tfsdk:"config"`
+	Labels  types.List   `tfsdk:"labels"`
+	Tags    types.List   `tfsdk:"tags"`
+}
+
+// ConfigValidators returns the cross-attribute rules of the configuration.
+func (r *promptEphemeralResource) ConfigValidators(ctx context.Context) []ephemeral.ConfigValidator {
+	return []ephemeral.ConfigValidator{
+		conflicting("label", "version"),
+	}
+}
+
+// Configure injects the Langfuse client.
+func (r *promptEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	clientData, ok := req.ProviderData.(client.LangfuseAPI)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected client.LangfuseAPI, got %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = clientData
+}
+
+// Open fetches the selected prompt version.
+func (r *promptEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data promptEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	prompt, err := r.client.GetPrompt(ctx, data.Name.ValueString(), client.PromptSelector{
+		Label:   data.Label.ValueString(),
+		Version: int(data.Version.ValueInt64()),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error fetching prompt", err)
+		return
+	}
+
+	// Text prompts are a JSON string; chat prompts stay JSON-encoded.
+	content := string(prompt.Prompt)
+	var text string
+	if err := json.Unmarshal(prompt.Prompt, &text); err == nil {
+		content = text
+	}
+	config := "{}"
+	if len(prompt.Config) > 0 && string(prompt.Config) != "null" {
+		config = string(prompt.Config)
+	}
+
+	data.Version = types.Int64Value(int64(prompt.Version))
+	data.Type = types.StringValue(prompt.Type)
+	data.Prompt = types.StringValue(content)
+	data.Config = types.StringValue(config)
+	var diags diag.Diagnostics
+	data.Labels, diags = types.ListValueFrom(ctx, types.StringType, prompt.Labels)
+	resp.Diagnostics.Append(diags...)
+	data.Tags, diags = types.ListValueFrom(ctx, types.StringType, prompt.Tags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
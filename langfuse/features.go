@@ -0,0 +1,56 @@
+package langfuse
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// featuresBlock returns the provider's features block, which opts into
+// experimental or risky behaviour. Everything in it defaults to off, so
+// such behaviour can ship before it is stable without surprising anyone.
+func featuresBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Opt into experimental behaviour of the provider. Features may change or be removed in minor releases while they are experimental.",
+		Blocks: map[string]schema.Block{
+			"organization": schema.SingleNestedBlock{
+				MarkdownDescription: "Behaviour of `langfuse_organization` resources.",
+				Attributes: map[string]schema.Attribute{
+					"delete_projects_on_destroy": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Delete all projects of an organization, including projects not managed by Terraform, before destroying the organization. Langfuse refuses to delete organizations that still have projects. **Experimental**, and irreversible: the traces and prompts of the deleted projects are lost. Defaults to `false`.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// featuresConfig holds the features block of the provider configuration. It
+// is nil when the block is absent.
+type featuresConfig struct {
+	Organization *organizationFeaturesConfig `tfsdk:"organization"`
+}
+
+// organizationFeaturesConfig holds the organization block of the features.
+type organizationFeaturesConfig struct {
+	DeleteProjectsOnDestroy types.Bool `tfsdk:"delete_projects_on_destroy"`
+}
+
+// features holds the enabled experimental features.
+type features struct {
+	// deleteProjectsOnDestroy makes organization deletion remove the
+	// organization's projects first.
+	deleteProjectsOnDestroy bool
+}
+
+// resolve returns the features enabled by c, which may be nil.
+func (c *featuresConfig) resolve() features {
+	var f features
+	if c == nil {
+		return f
+	}
+	if c.Organization != nil {
+		f.deleteProjectsOnDestroy = c.Organization.DeleteProjectsOnDestroy.ValueBool()
+	}
+	return f
+}
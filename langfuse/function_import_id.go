@@ -0,0 +1,69 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// importIDFunction implements the import_id provider function.
+type importIDFunction struct{}
+
+// NewImportIDFunction returns a new importIDFunction.
+func NewImportIDFunction() function.Function {
+	return &importIDFunction{}
+}
+
+// Metadata sets the function name.
+func (f *importIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "import_id"
+}
+
+// Definition describes the parameters and result of the function.
+func (f *importIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a langfuse_project import ID",
+		Description: "Returns the composite identifier \"<organization_id>/<project_id>\" accepted by `terraform import` and `import` blocks for langfuse_project, after checking that both parts are non-empty and contain no slash.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "organization_id",
+				Description: "ID of the organization owning the project.",
+			},
+			function.StringParameter{
+				Name:        "project_id",
+				Description: "ID of the project.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the import ID.
+func (f *importIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var orgID, projID string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &orgID, &projID))
+	if resp.Error != nil {
+		return
+	}
+	for i, part := range []struct{ name, value string }{{"organization_id", orgID}, {"project_id", projID}} {
+		if err := checkImportIDPart(part.name, part.value); err != nil {
+			resp.Error = function.NewArgumentFuncError(int64(i), err.Error())
+			return
+		}
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, orgID+"/"+projID))
+}
+
+// checkImportIDPart reports why value cannot be used as one part of a
+// composite import ID.
+func checkImportIDPart(name, value string) error {
+	switch {
+	case value == "":
+		return fmt.Errorf("%s must not be empty", name)
+	case strings.Contains(value, "/"):
+		return fmt.Errorf("%s must not contain \"/\", got %q", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package langfuse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestImportIDFunction(t *testing.T) {
+	tests := []struct {
+		orgID, projID string
+		want          string
+		wantErr       string
+	}{
+		{orgID: "org-1", projID: "proj-1", want: "org-1/proj-1"},
+		{orgID: "", projID: "proj-1", wantErr: "organization_id must not be empty"},
+		{orgID: "org-1", projID: "", wantErr: "project_id must not be empty"},
+		{orgID: "org/1", projID: "proj-1", wantErr: `organization_id must not contain "/"`},
+		{orgID: "org-1", projID: "a/b", wantErr: `project_id must not contain "/"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.orgID+"|"+tt.projID, func(t *testing.T) {
+			got, err := runFunction(t, NewImportIDFunction(), types.StringValue(tt.orgID), types.StringValue(tt.projID))
+			if tt.wantErr != "" {
+				requireFuncError(t, err, tt.wantErr)
+				return
+			}
+			if err != nil || !got.Equal(types.StringValue(tt.want)) {
+				t.Errorf("import_id = %s, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package langfuse
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// keyFingerprintFunction implements the key_fingerprint provider function.
+type keyFingerprintFunction struct{}
+
+// NewKeyFingerprintFunction returns a new keyFingerprintFunction.
+func NewKeyFingerprintFunction() function.Function {
+	return &keyFingerprintFunction{}
+}
+
+// keyFingerprint is the result of the key_fingerprint function.
+type keyFingerprint struct {
+	Display     string `tfsdk:"display"`
+	Fingerprint string `tfsdk:"fingerprint"`
+}
+
+// Metadata sets the function name.
+func (f *keyFingerprintFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "key_fingerprint"
+}
+
+// Definition describes the parameters and result of the function.
+func (f *keyFingerprintFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Derive displayable identifiers from an API key",
+		Description: "Returns an object with `display`, the key shortened the way the Langfuse UI shows it (`sk-lf-...` followed by the last four characters), and `fingerprint`, its SHA-256 fingerprint in the form `sha256:<hex>` as stored in `secret_key_fingerprint` of langfuse_project. Neither reveals the key.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "key",
+				Description: "Public or secret API key.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"display":     types.StringType,
+				"fingerprint": types.StringType,
+			},
+		},
+	}
+}
+
+// Run derives the identifiers.
+func (f *keyFingerprintFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &key))
+	if resp.Error != nil {
+		return
+	}
+	if key == "" {
+		resp.Error = function.NewArgumentFuncError(0, "key must not be empty")
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, keyFingerprint{
+		Display:     displayKey(key),
+		Fingerprint: secretKeyFingerprint(key),
+	}))
+}
+
+// displayKey shortens key to its "pk-lf-"/"sk-lf-" prefix and last four
+// characters. Keys too short to shorten safely are masked entirely.
+func displayKey(key string) string {
+	prefix := ""
+	for _, p := range []string{"pk-lf-", "sk-lf-"} {
+		if strings.HasPrefix(key, p) {
+			prefix = p
+		}
+	}
+	rest := key[len(prefix):]
+	if len(rest) < 12 {
+		return prefix + "..."
+	}
+	return prefix + "..." + rest[len(rest)-4:]
+}
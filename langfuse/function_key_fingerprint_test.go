@@ -0,0 +1,44 @@
+package langfuse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDisplayKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "sk-lf-0123456789abcdef", want: "sk-lf-...cdef"},
+		{key: "pk-lf-0123456789abcdef", want: "pk-lf-...cdef"},
+		{key: "sk-lf-0123", want: "sk-lf-..."},
+		{key: "sk-lf-", want: "sk-lf-..."},
+		{key: "0123456789abcdef", want: "...cdef"},
+		{key: "short", want: "..."},
+	}
+	for _, tt := range tests {
+		if got := displayKey(tt.key); got != tt.want {
+			t.Errorf("displayKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestKeyFingerprintFunction(t *testing.T) {
+	got, err := runFunction(t, NewKeyFingerprintFunction(), types.StringValue("sk-lf-0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.ObjectValueMust(map[string]attr.Type{"display": types.StringType, "fingerprint": types.StringType}, map[string]attr.Value{
+		"display":     types.StringValue("sk-lf-...cdef"),
+		"fingerprint": types.StringValue(secretKeyFingerprint("sk-lf-0123456789abcdef")),
+	})
+	if !got.Equal(want) {
+		t.Errorf("key_fingerprint = %s, want %s", got, want)
+	}
+
+	_, err = runFunction(t, NewKeyFingerprintFunction(), types.StringValue(""))
+	requireFuncError(t, err, "key must not be empty")
+}
@@ -0,0 +1,64 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// modelPatternMatchesFunction implements the model_pattern_matches provider
+// function.
+type modelPatternMatchesFunction struct{}
+
+// NewModelPatternMatchesFunction returns a new modelPatternMatchesFunction.
+func NewModelPatternMatchesFunction() function.Function {
+	return &modelPatternMatchesFunction{}
+}
+
+// Metadata sets the function name.
+func (f *modelPatternMatchesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "model_pattern_matches"
+}
+
+// Definition describes the parameters and result of the function.
+func (f *modelPatternMatchesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Test a model match pattern against model names",
+		Description: "Compiles a Langfuse model `match_pattern`, e.g. `(?i)^(gpt-4o)$`, and returns a map from each sample model name to whether the pattern matches it. Langfuse evaluates patterns in PostgreSQL; this function uses Go's RE2 syntax, which agrees for the patterns Langfuse uses but rejects backreferences and lookaround.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pattern",
+				Description: "Regular expression to test.",
+			},
+			function.ListParameter{
+				Name:        "model_names",
+				Description: "Sample model names, e.g. as reported by SDK integrations.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.MapReturn{ElementType: types.BoolType},
+	}
+}
+
+// Run compiles the pattern and matches it against the samples.
+func (f *modelPatternMatchesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pattern string
+	var names []string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pattern, &names))
+	if resp.Error != nil {
+		return
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid match pattern: %s", err))
+		return
+	}
+	matches := make(map[string]bool, len(names))
+	for _, name := range names {
+		matches[name] = re.MatchString(name)
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, matches))
+}
@@ -0,0 +1,46 @@
+package langfuse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestModelPatternMatchesFunction(t *testing.T) {
+	names := func(values ...string) types.List {
+		elems := make([]attr.Value, len(values))
+		for i, v := range values {
+			elems[i] = types.StringValue(v)
+		}
+		return types.ListValueMust(types.StringType, elems)
+	}
+	tests := []struct {
+		name    string
+		pattern string
+		models  types.List
+		want    map[string]bool
+		wantErr string
+	}{
+		{name: "case insensitive", pattern: "(?i)^(gpt-4o)$", models: names("gpt-4o", "GPT-4o", "gpt-4o-mini"), want: map[string]bool{"gpt-4o": true, "GPT-4o": true, "gpt-4o-mini": false}},
+		{name: "no samples", pattern: "^claude", models: names(), want: map[string]bool{}},
+		{name: "invalid regex", pattern: "(gpt-4", models: names("gpt-4"), wantErr: "invalid match pattern"},
+		{name: "lookahead", pattern: "^gpt(?!-3)", models: names("gpt-4"), wantErr: "invalid match pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runFunction(t, NewModelPatternMatchesFunction(), types.StringValue(tt.pattern), tt.models)
+			if tt.wantErr != "" {
+				requireFuncError(t, err, tt.wantErr)
+				return
+			}
+			elems := map[string]attr.Value{}
+			for k, v := range tt.want {
+				elems[k] = types.BoolValue(v)
+			}
+			if want := types.MapValueMust(types.BoolType, elems); err != nil || !got.Equal(want) {
+				t.Errorf("model_pattern_matches = %s, %v; want %s", got, err, want)
+			}
+		})
+	}
+}
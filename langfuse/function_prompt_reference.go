@@ -0,0 +1,98 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promptReferenceFunction implements the prompt_reference provider function.
+type promptReferenceFunction struct{}
+
+// NewPromptReferenceFunction returns a new promptReferenceFunction.
+func NewPromptReferenceFunction() function.Function {
+	return &promptReferenceFunction{}
+}
+
+// Metadata sets the function name.
+func (f *promptReferenceFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "prompt_reference"
+}
+
+// Definition describes the parameters and result of the function.
+func (f *promptReferenceFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build a Langfuse prompt reference",
+		Description: "Returns the reference Langfuse resolves to another prompt, `@@@langfusePrompt:name=<name>|label=<label>@@@` or `@@@langfusePrompt:name=<name>|version=<version>@@@`. Exactly one of label and version must be given; pass null for the other.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "name",
+				Description: "Name of the referenced prompt.",
+			},
+			function.StringParameter{
+				Name:           "label",
+				Description:    "Label of the referenced version, e.g. \"production\", or null.",
+				AllowNullValue: true,
+			},
+			function.Int64Parameter{
+				Name:           "version",
+				Description:    "Referenced version number, or null.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the reference.
+func (f *promptReferenceFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+	var label types.String
+	var version types.Int64
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &name, &label, &version))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := checkPromptReferencePart("name", name); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	var selector string
+	switch {
+	case !label.IsNull() && !version.IsNull():
+		resp.Error = function.NewFuncError("only one of label and version can be given")
+		return
+	case !label.IsNull():
+		if err := checkPromptReferencePart("label", label.ValueString()); err != nil {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+			return
+		}
+		selector = "label=" + label.ValueString()
+	case !version.IsNull():
+		if version.ValueInt64() < 1 {
+			resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("version must be at least 1, got %d", version.ValueInt64()))
+			return
+		}
+		selector = fmt.Sprintf("version=%d", version.ValueInt64())
+	default:
+		resp.Error = function.NewFuncError("one of label and version must be given")
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, "@@@langfusePrompt:name="+name+"|"+selector+"@@@"))
+}
+
+// checkPromptReferencePart reports why value cannot appear in a prompt
+// reference, where "|" separates fields and "@@@" ends the reference.
+func checkPromptReferencePart(name, value string) error {
+	switch {
+	case value == "":
+		return fmt.Errorf("%s must not be empty", name)
+	case strings.Contains(value, "|"), strings.Contains(value, "@@@"):
+		return fmt.Errorf("%s must not contain \"|\" or \"@@@\", got %q", name, value)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package langfuse
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPromptReferenceFunction(t *testing.T) {
+	tests := []struct {
+		name    string
+		prompt  string
+		label   types.String
+		version types.Int64
+		want    string
+		wantErr string
+	}{
+		{name: "label", prompt: "greeting", label: types.StringValue("production"), version: types.Int64Null(), want: "@@@langfusePrompt:name=greeting|label=production@@@"},
+		{name: "version", prompt: "greeting", label: types.StringNull(), version: types.Int64Value(3), want: "@@@langfusePrompt:name=greeting|version=3@@@"},
+		{name: "label and version", prompt: "greeting", label: types.StringValue("production"), version: types.Int64Value(3), wantErr: "only one of label and version can be given"},
+		{name: "neither", prompt: "greeting", label: types.StringNull(), version: types.Int64Null(), wantErr: "one of label and version must be given"},
+		{name: "empty name", prompt: "", label: types.StringValue("production"), version: types.Int64Null(), wantErr: "name must not be empty"},
+		{name: "separator in name", prompt: "a|b", label: types.StringValue("production"), version: types.Int64Null(), wantErr: `name must not contain "|" or "@@@"`},
+		{name: "terminator in label", prompt: "greeting", label: types.StringValue("prod@@@"), version: types.Int64Null(), wantErr: `label must not contain "|" or "@@@"`},
+		{name: "empty label", prompt: "greeting", label: types.StringValue(""), version: types.Int64Null(), wantErr: "label must not be empty"},
+		{name: "version zero", prompt: "greeting", label: types.StringNull(), version: types.Int64Value(0), wantErr: "version must be at least 1, got 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runFunction(t, NewPromptReferenceFunction(), types.StringValue(tt.prompt), tt.label, tt.version)
+			if tt.wantErr != "" {
+				requireFuncError(t, err, tt.wantErr)
+				return
+			}
+			if err != nil || !got.Equal(types.StringValue(tt.want)) {
+				t.Errorf("prompt_reference = %s, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
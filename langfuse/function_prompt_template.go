@@ -0,0 +1,88 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promptVariablePattern matches the variable names Langfuse substitutes in
+// {{variable}} placeholders.
+var promptVariablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validatePromptTemplateFunction implements the validate_prompt_template
+// provider function.
+type validatePromptTemplateFunction struct{}
+
+// NewValidatePromptTemplateFunction returns a new validatePromptTemplateFunction.
+func NewValidatePromptTemplateFunction() function.Function {
+	return &validatePromptTemplateFunction{}
+}
+
+// Metadata sets the function name.
+func (f *validatePromptTemplateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_prompt_template"
+}
+
+// Definition describes the parameters and result of the function.
+func (f *validatePromptTemplateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Check a prompt template and list its variables",
+		Description: "Checks that every `{{` in a Langfuse prompt template is closed by `}}` around a valid variable name (letters, digits and underscores, not starting with a digit; surrounding spaces are allowed) and returns the distinct variable names in order of first use. A `}}` without a preceding `{{`, e.g. in an embedded JSON example, is literal text, as it is for Langfuse. Malformed templates fail with an error naming the offending position.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "Prompt text to check.",
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+// Run checks the template and returns its variables.
+func (f *validatePromptTemplateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &template))
+	if resp.Error != nil {
+		return
+	}
+	vars, err := promptVariables(template)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, vars))
+}
+
+// promptVariables returns the distinct variable names used in template, in
+// order of first use. Like Langfuse when compiling a prompt, it treats "}}"
+// without a preceding "{{" as literal text, e.g. in embedded JSON. Offsets in
+// errors are byte offsets into template.
+func promptVariables(template string) ([]string, error) {
+	vars := []string{}
+	seen := map[string]bool{}
+	for offset := 0; ; {
+		open := strings.Index(template[offset:], "{{")
+		if open < 0 {
+			return vars, nil
+		}
+		start := offset + open + 2
+		end := strings.Index(template[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("\"{{\" at offset %d is not closed", start-2)
+		}
+		name := strings.TrimSpace(template[start : start+end])
+		if !promptVariablePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid variable name %q at offset %d", name, start-2)
+		}
+		if !seen[name] {
+			seen[name] = true
+			vars = append(vars, name)
+		}
+		offset = start + end + 2
+	}
+}
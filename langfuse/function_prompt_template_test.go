@@ -0,0 +1,41 @@
+package langfuse
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPromptVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+		wantErr  string
+	}{
+		{name: "no variables", template: "Hello world", want: []string{}},
+		{name: "variables", template: "Hello {{name}}, welcome to {{place}}", want: []string{"name", "place"}},
+		{name: "padded names", template: "Hello {{ name }} and {{\tother\t}}", want: []string{"name", "other"}},
+		{name: "duplicates", template: "{{a}} {{b}} {{a}} {{ b }}", want: []string{"a", "b"}},
+		{name: "nested json", template: `Answer like {"a": {"b": 1}} for {{question}}`, want: []string{"question"}},
+		{name: "stray closing braces", template: "}} {{x}} }}", want: []string{"x"}},
+		{name: "unclosed", template: "Hello {{name", wantErr: `"{{" at offset 6 is not closed`},
+		{name: "empty name", template: "{{ }}", wantErr: `invalid variable name "" at offset 0`},
+		{name: "invalid name", template: "a {{1st}}", wantErr: `invalid variable name "1st" at offset 2`},
+		{name: "json inside placeholder", template: `{{"a": 1}}`, wantErr: "invalid variable name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := promptVariables(tt.template)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("promptVariables(%q) = %q, %v; want error %q", tt.template, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("promptVariables(%q) = %q, %v; want %q", tt.template, got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package langfuse
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseProjectImportID(f *testing.F) {
+	for _, seed := range []string{"", "/", "//", "proj", "org/proj", "org/", "/proj", "a/b/c", "org%2Fx/proj", " org / proj ", "org/proj/"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, id string) {
+		orgID, projID, err := parseProjectImportID(id)
+		if err != nil {
+			if orgID != "" || projID != "" {
+				t.Fatalf("parseProjectImportID(%q) returned IDs %q, %q along with error %v", id, orgID, projID, err)
+			}
+			return
+		}
+		if projID == "" || strings.Contains(projID, "/") || strings.Contains(orgID, "/") {
+			t.Fatalf("parseProjectImportID(%q) = %q, %q: parts must be non-empty and free of slashes", id, orgID, projID)
+		}
+		// Accepted IDs are reproduced exactly by their parts.
+		want := projID
+		if orgID != "" {
+			want = orgID + "/" + projID
+		}
+		if want != id {
+			t.Fatalf("parseProjectImportID(%q) = %q, %q, which does not rebuild the ID", id, orgID, projID)
+		}
+		if orgID != "" && checkImportIDPart("organization_id", orgID) != nil {
+			t.Fatalf("import_id would reject the parts of accepted ID %q", id)
+		}
+	})
+}
+
+func FuzzParseOrganizationImportID(f *testing.F) {
+	for _, seed := range []string{"", "org", "name:", "name:team", "name:name:team", "Name:team", "org/x", "name:a/b"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, importID string) {
+		id, name, err := parseOrganizationImportID(importID)
+		switch {
+		case err != nil:
+			if id != "" || name != "" {
+				t.Fatalf("parseOrganizationImportID(%q) returned %q, %q along with error %v", importID, id, name, err)
+			}
+		case (id == "") == (name == ""):
+			t.Fatalf("parseOrganizationImportID(%q) = %q, %q: exactly one must be set", importID, id, name)
+		case name != "" && "name:"+name != importID:
+			t.Fatalf("parseOrganizationImportID(%q) returned name %q", importID, name)
+		case id != "" && (id != importID || strings.HasPrefix(id, "name:")):
+			t.Fatalf("parseOrganizationImportID(%q) returned ID %q", importID, id)
+		}
+	})
+}
+
+func FuzzParseAPIKeyImportID(f *testing.F) {
+	for _, seed := range []string{"", "//", "org/proj/key", "org/proj", "org//key", "/proj/key", "org/proj/key/", "a/b/c/d"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, id string) {
+		orgID, projID, keyID, err := parseAPIKeyImportID(id)
+		if err != nil {
+			if orgID != "" || projID != "" || keyID != "" {
+				t.Fatalf("parseAPIKeyImportID(%q) returned IDs %q, %q, %q along with error %v", id, orgID, projID, keyID, err)
+			}
+			return
+		}
+		if orgID+"/"+projID+"/"+keyID != id {
+			t.Fatalf("parseAPIKeyImportID(%q) = %q, %q, %q, which does not rebuild the ID", id, orgID, projID, keyID)
+		}
+		for _, part := range []string{orgID, projID, keyID} {
+			if part == "" || strings.Contains(part, "/") {
+				t.Fatalf("parseAPIKeyImportID(%q) = %q, %q, %q: parts must be non-empty and free of slashes", id, orgID, projID, keyID)
+			}
+		}
+	})
+}
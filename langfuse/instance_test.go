@@ -0,0 +1,216 @@
+package langfuse
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// Environment variables configuring acceptance tests against a real,
+// self-hosted Langfuse instance. Objects created by the tests carry
+// testAccNamePrefix and can be removed with the sweepers.
+const (
+	envBaseURL     = "LANGFUSE_BASE_URL"
+	envAdminAPIKey = "LANGFUSE_ADMIN_API_KEY"
+	// envPublicKey, envSecretKey and envPromptName select an existing prompt
+	// that the prompt test reads; the test is skipped without them.
+	envPublicKey  = "LANGFUSE_PUBLIC_KEY"
+	envSecretKey  = "LANGFUSE_SECRET_KEY"
+	envPromptName = "LANGFUSE_ACC_PROMPT_NAME"
+)
+
+var (
+	publicKeyPattern = regexp.MustCompile(`^pk-lf-`)
+	secretKeyPattern = regexp.MustCompile(`^sk-lf-`)
+)
+
+// testAccInstancePreCheck skips the test unless the named environment
+// variables are set, in addition to those locating the instance.
+func testAccInstancePreCheck(t *testing.T, extra ...string) {
+	t.Helper()
+	for _, name := range append([]string{envBaseURL, envAdminAPIKey}, extra...) {
+		if os.Getenv(name) == "" {
+			t.Skipf("%s must be set for acceptance tests against a Langfuse instance", name)
+		}
+	}
+}
+
+// testAccInstanceProviderConfig returns a provider block for the instance
+// named by the environment.
+func testAccInstanceProviderConfig() string {
+	return fmt.Sprintf(`
+provider "langfuse" {
+  base_url      = %q
+  admin_api_key = %q
+  public_key    = %q
+  secret_key    = %q
+}
+`, os.Getenv(envBaseURL), os.Getenv(envAdminAPIKey), os.Getenv(envPublicKey), os.Getenv(envSecretKey))
+}
+
+// TestAccInstanceLifecycle creates an organization with a project and an
+// additional API key on the instance, looks the key up with the data source,
+// renames the organization and project and checks that the keys survive the
+// update, rotates the additional key, imports all three and finally destroys
+// them.
+func TestAccInstanceLifecycle(t *testing.T) {
+	suffix := acctest.RandString(8)
+	config := func(rename, rotation string) string {
+		return testAccInstanceProviderConfig() + fmt.Sprintf(`
+resource "langfuse_organization" "test" {
+  name = "%[1]sorg-%[2]s%[3]s"
+}
+
+resource "langfuse_project" "test" {
+  name            = "%[1]sproject-%[2]s%[3]s"
+  organization_id = langfuse_organization.test.id
+  metadata = {
+    purpose = "acceptance test"
+  }
+}
+
+resource "langfuse_api_key" "test" {
+  organization_id = langfuse_organization.test.id
+  project_id      = langfuse_project.test.id
+  note            = "acceptance test"
+  max_age         = "720h"
+  rotation_trigger = {
+    rotation = "%[4]s"
+  }
+}
+
+data "langfuse_api_key" "test" {
+  organization_id = langfuse_organization.test.id
+  project_id      = langfuse_project.test.id
+  public_key      = langfuse_api_key.test.public_key
+}
+
+output "key" {
+  value     = provider::langfuse::key_fingerprint(langfuse_project.test.secret_key).fingerprint
+  sensitive = true
+}
+`, testAccNamePrefix, suffix, rename, rotation)
+	}
+	// checkAPIKey checks the additional key of the project, which the data
+	// source must find by its public key.
+	checkAPIKey := resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttrPair("langfuse_api_key.test", "project_id", "langfuse_project.test", "id"),
+		resource.TestMatchResourceAttr("langfuse_api_key.test", "public_key", publicKeyPattern),
+		resource.TestMatchResourceAttr("langfuse_api_key.test", "secret_key", secretKeyPattern),
+		resource.TestCheckResourceAttr("langfuse_api_key.test", "note", "acceptance test"),
+		resource.TestCheckResourceAttrSet("langfuse_api_key.test", "expires_at"),
+		resource.TestCheckResourceAttr("data.langfuse_api_key.test", "exists", "true"),
+		resource.TestCheckResourceAttrPair("data.langfuse_api_key.test", "id", "langfuse_api_key.test", "id"),
+		resource.TestCheckResourceAttrPair("data.langfuse_api_key.test", "display_secret_key", "langfuse_api_key.test", "display_secret_key"),
+		resource.TestCheckResourceAttr("data.langfuse_api_key.test", "note", "acceptance test"),
+	)
+	apiKeyID := func(s *terraform.State) string {
+		return s.RootModule().Resources["langfuse_api_key.test"].Primary.ID
+	}
+
+	var keyFingerprint, keyID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccInstancePreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0), // provider functions
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkAPIKey,
+					resource.TestCheckResourceAttrSet("langfuse_organization.test", "id"),
+					resource.TestCheckResourceAttrPair("langfuse_project.test", "organization_id", "langfuse_organization.test", "id"),
+					resource.TestMatchResourceAttr("langfuse_project.test", "public_key", publicKeyPattern),
+					resource.TestMatchResourceAttr("langfuse_project.test", "secret_key", secretKeyPattern),
+					func(s *terraform.State) error {
+						keyFingerprint = s.RootModule().Resources["langfuse_project.test"].Primary.Attributes["secret_key_fingerprint"]
+						keyID = apiKeyID(s)
+						return resource.TestCheckOutput("key", keyFingerprint)(s)
+					},
+				),
+			},
+			{
+				Config: config("-renamed", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("langfuse_organization.test", "name", testAccNamePrefix+"org-"+suffix+"-renamed"),
+					resource.TestCheckResourceAttr("langfuse_project.test", "name", testAccNamePrefix+"project-"+suffix+"-renamed"),
+					func(s *terraform.State) error {
+						got := s.RootModule().Resources["langfuse_project.test"].Primary.Attributes["secret_key_fingerprint"]
+						if got != keyFingerprint {
+							return fmt.Errorf("secret key changed on update: fingerprint %s, was %s", got, keyFingerprint)
+						}
+						if got := apiKeyID(s); got != keyID {
+							return fmt.Errorf("API key replaced on rename: %s, was %s", got, keyID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Changing rotation_trigger replaces the additional key.
+				Config: config("-renamed", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					checkAPIKey,
+					func(s *terraform.State) error {
+						if got := apiKeyID(s); got == keyID {
+							return fmt.Errorf("API key %s not rotated", got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "langfuse_organization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "langfuse_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The secret key is only returned on create.
+				ImportStateVerifyIgnore: []string{"secret_key", "secret_key_fingerprint"},
+			},
+			{
+				ResourceName: "langfuse_api_key.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					attrs := s.RootModule().Resources["langfuse_api_key.test"].Primary.Attributes
+					return attrs["organization_id"] + "/" + attrs["project_id"] + "/" + attrs["id"], nil
+				},
+				ImportStateVerify: true,
+				// Only configuration and the create response know these.
+				ImportStateVerifyIgnore: []string{"secret_key", "rotation_trigger", "max_age", "expires_at"},
+			},
+		},
+	})
+}
+
+// TestAccInstancePrompt reads an existing prompt of the project whose public
+// API keys are configured.
+func TestAccInstancePrompt(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccInstancePreCheck(t, envPublicKey, envSecretKey, envPromptName) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0), // ephemeral resources
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccInstanceProviderConfig() + fmt.Sprintf(`
+ephemeral "langfuse_prompt" "test" {
+  name = %q
+}
+`, os.Getenv(envPromptName)),
+			},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package langfuse
+
+import (
+	"context"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewOrganizationListResource returns the langfuse_organization list
+// resource, which enumerates organizations for terraform query. It shares its
+// implementation with the managed resource.
+func NewOrganizationListResource() list.ListResource {
+	return &organizationResource{}
+}
+
+var _ list.ListResourceWithConfigure = &organizationResource{}
+
+// ListResourceConfigSchema defines the configuration of list blocks; listing
+// organizations takes no arguments.
+func (r *organizationResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists every organization of the Langfuse instance, e.g. to generate import blocks for organizations not yet managed by Terraform.",
+	}
+}
+
+// List streams the organizations of the instance, up to the requested limit.
+func (r *organizationResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		defer logClientMetrics(ctx, r.client)
+		ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+		defer cancel()
+
+		orgs, err := r.client.ListOrganizations(ctx)
+		if err != nil {
+			var diags diag.Diagnostics
+			addClientError(&diags, "Error listing organizations", err)
+			push(list.ListResult{Diagnostics: diags})
+			return
+		}
+		for i, org := range orgs {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			result := req.NewListResult(ctx)
+			result.DisplayName = org.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, organizationIdentityModel{ID: types.StringValue(org.ID)})...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, listedOrganizationModel(org))...)
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listedOrganizationModel returns the state of org as an import would
+// produce it.
+func listedOrganizationModel(org client.Organization) organizationResourceModel {
+	return organizationResourceModel{
+		ID:         types.StringValue(org.ID),
+		Name:       types.StringValue(org.Name),
+		IgnoreCase: types.BoolNull(),
+		Timeouts:   nullTimeouts(),
+	}
+}
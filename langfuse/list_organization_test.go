@@ -0,0 +1,65 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+)
+
+func TestOrganizationListResource(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	orgA, _ := fake.CreateOrganization(ctx, "team-a")
+	orgB, _ := fake.CreateOrganization(ctx, "team-b")
+	r := &organizationResource{client: fake}
+
+	tests := []struct {
+		name            string
+		limit           int64
+		includeResource bool
+		wantNames       []string
+	}{
+		{name: "identities", wantNames: []string{orgA.Name, orgB.Name}},
+		{name: "resources", includeResource: true, wantNames: []string{orgA.Name, orgB.Name}},
+		{name: "limited", limit: 1, wantNames: []string{orgA.Name}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := listResources(t, r, struct{}{}, tt.limit, tt.includeResource)
+			if len(results) != len(tt.wantNames) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantNames))
+			}
+			for i, result := range results {
+				requireNoErrors(t, result.Diagnostics)
+				if result.DisplayName != tt.wantNames[i] {
+					t.Errorf("result %d is %q, want %q", i, result.DisplayName, tt.wantNames[i])
+				}
+				var identity organizationIdentityModel
+				requireNoErrors(t, result.Identity.Get(ctx, &identity))
+				if identity.ID.IsNull() {
+					t.Errorf("result %d has no identity", i)
+				}
+				if result.Resource.Raw.IsNull() == tt.includeResource {
+					t.Errorf("result %d has resource %s, want included: %t", i, result.Resource.Raw, tt.includeResource)
+				}
+				if !tt.includeResource {
+					continue
+				}
+				var got organizationResourceModel
+				requireNoErrors(t, result.Resource.Get(ctx, &got))
+				if got.ID != identity.ID || got.Name.ValueString() != tt.wantNames[i] {
+					t.Errorf("result %d resource = %s %s, want %s %s", i, got.ID, got.Name, identity.ID, tt.wantNames[i])
+				}
+			}
+		})
+	}
+
+	fake.Err = errors.New("connection refused")
+	results := listResources(t, r, struct{}{}, 0, false)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	requireError(t, results[0].Diagnostics, "Error listing organizations")
+}
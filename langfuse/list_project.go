@@ -0,0 +1,130 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// NewProjectListResource returns the langfuse_project list resource, which
+// enumerates projects for terraform query. It shares its implementation with
+// the managed resource.
+func NewProjectListResource() list.ListResource {
+	return &projectResource{}
+}
+
+var _ list.ListResourceWithConfigure = &projectResource{}
+
+// projectListConfigModel maps the configuration of langfuse_project list blocks.
+type projectListConfigModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+// ListResourceConfigSchema defines the configuration of list blocks.
+func (r *projectResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists Langfuse projects, e.g. to generate import blocks for projects not yet managed by Terraform.",
+		Attributes: map[string]listschema.Attribute{
+			"organization_id": listschema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the organization whose projects are listed. Defaults to the projects of all organizations.",
+			},
+		},
+	}
+}
+
+// List streams the projects of the configured organization, or of every
+// organization, up to the requested limit.
+func (r *projectResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config projectListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		defer logClientMetrics(ctx, r.client)
+		ctx, cancel := context.WithTimeout(ctx, defaultReadTimeout)
+		defer cancel()
+
+		orgIDs := []string{config.OrganizationID.ValueString()}
+		if config.OrganizationID.IsNull() {
+			orgs, err := r.client.ListOrganizations(ctx)
+			if err != nil {
+				var diags diag.Diagnostics
+				addClientError(&diags, "Error listing organizations", err)
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			orgIDs = orgIDs[:0]
+			for _, org := range orgs {
+				orgIDs = append(orgIDs, org.ID)
+			}
+		}
+
+		var n int64
+		for _, orgID := range orgIDs {
+			projects, err := r.client.ListProjects(ctx, orgID)
+			if err != nil {
+				var diags diag.Diagnostics
+				addClientError(&diags, fmt.Sprintf("Error listing the projects of organization %s", orgID), err)
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+			for _, proj := range projects {
+				if req.Limit > 0 && n >= req.Limit {
+					return
+				}
+				n++
+				if proj.OrganizationID == "" {
+					proj.OrganizationID = orgID
+				}
+				if !push(r.listResult(ctx, req, proj)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// listResult returns the result for proj, with its state as an import would
+// produce it if the resource was requested.
+func (r *projectResource) listResult(ctx context.Context, req list.ListRequest, proj client.Project) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = proj.Name
+	result.Diagnostics.Append(result.Identity.Set(ctx, projectIdentityModel{
+		OrganizationID: types.StringValue(proj.OrganizationID),
+		ID:             types.StringValue(proj.ID),
+	})...)
+	if !req.IncludeResource {
+		return result
+	}
+
+	metadata := types.MapNull(types.StringType)
+	if len(proj.Metadata) > 0 {
+		var diags diag.Diagnostics
+		metadata, diags = projectMetadataValue(proj.Metadata)
+		result.Diagnostics.Append(diags...)
+	}
+	result.Diagnostics.Append(result.Resource.Set(ctx, projectResourceModel{
+		ID:             types.StringValue(proj.ID),
+		Name:           types.StringValue(proj.Name),
+		IgnoreCase:     types.BoolNull(),
+		OrganizationID: types.StringValue(proj.OrganizationID),
+		Metadata:       metadata,
+		DuplicateCheck: types.StringNull(),
+		PublicKey:      types.StringValue(proj.PublicKey),
+		SecretKey:      types.StringNull(),
+		SecretKeyHash:  types.StringNull(),
+		StoreSecretKey: types.BoolValue(true),
+		SecretKeyCmd:   types.ListNull(types.StringType),
+		Timeouts:       nullTimeouts(),
+	})...)
+	return result
+}
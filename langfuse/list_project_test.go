@@ -0,0 +1,78 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProjectListResource(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	orgA, _ := fake.CreateOrganization(ctx, "team-a")
+	orgB, _ := fake.CreateOrganization(ctx, "team-b")
+	search, _ := fake.CreateProject(ctx, orgA.ID, client.ProjectCreate{Name: "search", Metadata: map[string]any{"tier": "gold"}})
+	chat, _ := fake.CreateProject(ctx, orgB.ID, client.ProjectCreate{Name: "chat"})
+	r := &projectResource{client: fake}
+
+	tests := []struct {
+		name            string
+		orgID           types.String
+		limit           int64
+		includeResource bool
+		want            []*client.Project
+	}{
+		{name: "all organizations", orgID: types.StringNull(), want: []*client.Project{search, chat}},
+		{name: "one organization", orgID: types.StringValue(orgB.ID), want: []*client.Project{chat}},
+		{name: "resources", orgID: types.StringNull(), includeResource: true, want: []*client.Project{search, chat}},
+		{name: "limited", orgID: types.StringNull(), limit: 1, want: []*client.Project{search}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := listResources(t, r, projectListConfigModel{OrganizationID: tt.orgID}, tt.limit, tt.includeResource)
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.want))
+			}
+			for i, result := range results {
+				want := tt.want[i]
+				requireNoErrors(t, result.Diagnostics)
+				var identity projectIdentityModel
+				requireNoErrors(t, result.Identity.Get(ctx, &identity))
+				if result.DisplayName != want.Name || identity.OrganizationID.ValueString() != want.OrganizationID || identity.ID.ValueString() != want.ID {
+					t.Errorf("result %d is %q %s/%s, want %q %s/%s", i, result.DisplayName, identity.OrganizationID, identity.ID, want.Name, want.OrganizationID, want.ID)
+				}
+				if result.Resource.Raw.IsNull() == tt.includeResource {
+					t.Errorf("result %d has resource %s, want included: %t", i, result.Resource.Raw, tt.includeResource)
+				}
+				if !tt.includeResource {
+					continue
+				}
+				var got projectResourceModel
+				requireNoErrors(t, result.Resource.Get(ctx, &got))
+				if got.PublicKey.ValueString() != want.PublicKey || !got.SecretKey.IsNull() {
+					t.Errorf("result %d keys = %s, %s; want %s and no secret key", i, got.PublicKey, got.SecretKey, want.PublicKey)
+				}
+				if wantMetadata := len(want.Metadata) > 0; got.Metadata.IsNull() == wantMetadata {
+					t.Errorf("result %d metadata = %s, want %v", i, got.Metadata, want.Metadata)
+				}
+			}
+		})
+	}
+
+	results := listResources(t, r, projectListConfigModel{OrganizationID: types.StringValue("org-missing")}, 0, false)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	requireError(t, results[0].Diagnostics, "Error listing the projects of organization org-missing")
+
+	fake.Err = errors.New("connection refused")
+	results = listResources(t, r, projectListConfigModel{OrganizationID: types.StringNull()}, 0, false)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	requireError(t, results[0].Diagnostics, "Error listing organizations")
+}
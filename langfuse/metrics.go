@@ -0,0 +1,16 @@
+package langfuse
+
+import (
+	"context"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+)
+
+// logClientMetrics writes the requests api sent since the previous call to
+// the Terraform log. It is deferred by every resource operation and data
+// source read, so each logs the requests made since the one before it.
+func logClientMetrics(ctx context.Context, api client.LangfuseAPI) {
+	if m, ok := api.(interface{ LogMetrics(context.Context) }); ok {
+		m.LogMetrics(ctx)
+	}
+}
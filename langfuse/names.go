@@ -0,0 +1,28 @@
+package langfuse
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sameName reports whether a configured name and the name returned by the API
+// only differ cosmetically: in surrounding whitespace and, with ignoreCase,
+// in letter case.
+func sameName(configured, remote string, ignoreCase bool) bool {
+	configured, remote = strings.TrimSpace(configured), strings.TrimSpace(remote)
+	if ignoreCase {
+		return strings.EqualFold(configured, remote)
+	}
+	return configured == remote
+}
+
+// remoteName returns the name to store for a name read from the API. The prior
+// value is kept when the two only differ cosmetically, so names normalized by
+// Langfuse do not show up as changes on every plan.
+func remoteName(prior types.String, remote string, ignoreCase types.Bool) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() && sameName(prior.ValueString(), remote, ignoreCase.ValueBool()) {
+		return prior
+	}
+	return types.StringValue(remote)
+}
@@ -1,12 +1,29 @@
 package langfuse
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/faxe1008/terraform-provider-langfuse/client"
 )
@@ -21,33 +38,257 @@ type LangfuseProvider struct {
 	version string
 }
 
+var (
+	_ provider.ProviderWithValidateConfig     = &LangfuseProvider{}
+	_ provider.ProviderWithConfigValidators   = &LangfuseProvider{}
+	_ provider.ProviderWithEphemeralResources = &LangfuseProvider{}
+	_ provider.ProviderWithFunctions          = &LangfuseProvider{}
+	_ provider.ProviderWithListResources      = &LangfuseProvider{}
+	_ provider.ProviderWithActions            = &LangfuseProvider{}
+)
+
+// defaultBaseURL is used when base_url is not configured.
+const defaultBaseURL = "http://localhost:3000"
+
 // Metadata returns the provider type name and version.
 func (p *LangfuseProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "langfuse"
 	resp.Version = p.version
 }
 
-// Schema defines provider-level configuration (admin_api_key, base_url).
+// Schema defines provider-level configuration (credentials, base_url and client tuning).
 func (p *LangfuseProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"admin_api_key": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
-				MarkdownDescription: "Langfuse **Admin API Key** (for self-hosted instances; used as a Bearer token).",
+				MarkdownDescription: "Langfuse **Admin API Key** (for self-hosted instances; used as a Bearer token). Conflicts with `admin_api_key_file` and `api_key_command`.",
+			},
+			"admin_api_key_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path to a file containing the Admin API Key (e.g. a mounted Kubernetes secret). The file is read at configure time and surrounding whitespace is trimmed. Conflicts with `admin_api_key` and `api_key_command`.",
+			},
+			"api_key_command": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Command (program followed by its arguments, e.g. `[\"vault\", \"kv\", \"get\", \"-field=key\", \"secret/langfuse\"]`) executed at configure time whose standard output is used as the Admin API Key. Conflicts with `admin_api_key` and `admin_api_key_file`.",
+			},
+			"public_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Project **public key** (`pk-lf-...`) used for requests to the Langfuse public API. Must be set together with `secret_key`. The admin API always uses the Admin API Key.",
+			},
+			"secret_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Project **secret key** (`sk-lf-...`) used for requests to the Langfuse public API. Must be set together with `public_key`.",
 			},
 			"base_url": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Base URL of the Langfuse API (e.g. `http://localhost:3000`). Defaults to `http://localhost:3000`.",
+				MarkdownDescription: "Base URL of the Langfuse API (e.g. `http://localhost:3000`). May include a path prefix when Langfuse is served below a sub-path behind a reverse proxy (e.g. `https://tools.example.com/langfuse`). Plain `http` is only accepted for local hosts unless `allow_insecure_http` is set. Use `unix:///path/to/langfuse.sock` to connect through a Unix domain socket. Defaults to `http://localhost:3000`.",
+			},
+			"allow_insecure_http": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Allow a plain `http` `base_url` for hosts other than `localhost`/loopback addresses. Credentials are then sent unencrypted. Defaults to `false`.",
+			},
+			"host_header": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Value sent as the HTTP `Host` header instead of the host from `base_url`, e.g. when addressing a virtual host through a load balancer.",
+			},
+			"dial_address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Address (IP or hostname, optionally with `:port`) to connect to instead of resolving the `base_url` host. TLS server name verification still uses the `base_url` host. Useful when the instance is only reachable through an internal load balancer IP.",
+			},
+			"keep_alive": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "TCP keep-alive period for connections to Langfuse as a Go duration (e.g. `30s`). Defaults to `30s`.",
+			},
+			"disable_keep_alives": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Disable HTTP keep-alive so every request opens a new connection. Defaults to `false`.",
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of idle connections kept open for reuse. Raise this for large applies through a proxy. Defaults to `10`, matching Terraform's default parallelism.",
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long an idle connection is kept before it is closed, as a Go duration (e.g. `90s`). Defaults to `90s`.",
+			},
+			"enable_http2": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether HTTP/2 may be negotiated with the server. Set to `false` for proxies that mishandle HTTP/2. Defaults to `true`.",
+			},
+			"compression": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Request gzip-compressed responses and decompress them in the provider. Reduces refresh time over slow links. Defaults to `true`.",
+			},
+			"max_redirects": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of HTTP redirects followed per request; `0` disables following redirects. Credentials are only sent along when the redirect stays on the same host and port, and redirects from `https` to `http` are refused. Defaults to `5`.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Timeout for a single API request attempt as a Go duration (e.g. `30s`). Applies in addition to Terraform's operation deadline, so a stuck request fails and can be retried. No per-request timeout when unset.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries for requests that failed with a transient error (rate limiting, gateway errors, connection failures). Set to `0` to disable retries. Defaults to `%d`.", client.DefaultRetryPolicy.MaxRetries),
+			},
+			"retry_jitter": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Randomize the wait between retries so that many resources retrying at once do not hit the API in lockstep. Defaults to `true`.",
+			},
+			"retry_max_elapsed_time": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Upper bound on the total time spent retrying a single request, as a Go duration (e.g. `2m`). Defaults to `%s`.", client.DefaultRetryPolicy.MaxElapsedTime),
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of API requests the provider sends concurrently, independent of Terraform's `-parallelism`. Useful for small self-hosted instances. Unlimited when unset or `0`.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("Number of items requested per page when the provider lists objects (e.g. for lookups). Larger pages mean fewer requests but bigger payloads. Defaults to `%d`.", client.DefaultPageSize),
+			},
+			"read_cache": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cache organization and project reads for the duration of one Terraform operation, so configurations with many resources referencing the same objects do not repeat identical requests. Projects are refreshed in batches: the project list of each organization is fetched once and serves the reads of all its projects. Writes made by the provider invalidate the cache. Defaults to `true`.",
+			},
+			"read_after_create": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Read newly created organizations and projects back, waiting until the API returns them, before recording them in state. This guards against instances whose reads lag behind writes. The create responses already contain the full objects, so disabling it saves one request per created object, e.g. when bootstrapping many projects. Defaults to `true`.",
+			},
+			"unknown_response_fields": schema.StringAttribute{
+				Optional:            true,
+				Validators:          []validator.String{stringOneOf("ignore", "warn", "error")},
+				MarkdownDescription: "How to handle fields in Langfuse API responses that the provider does not know about, which usually means the instance is newer than the provider: `ignore` drops them, `warn` logs a warning once per field and operation, `error` fails the operation. Defaults to `ignore`.",
+			},
+			"otel_tracing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Emit an OpenTelemetry span for every Langfuse API request and propagate the trace context to the server. Spans are exported over OTLP/HTTP. Defaults to `false`.",
+			},
+			"otel_endpoint": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "OTLP/HTTP endpoint URL for exported spans (e.g. `https://otel-collector.example.com:4318/v1/traces`). Defaults to the standard `OTEL_EXPORTER_OTLP_*` environment variables.",
+			},
+			"otel_headers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Additional headers sent with exported spans, e.g. collector authentication.",
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When `true`, the provider lists organizations during configuration to verify that `base_url` is reachable and the Admin API key is accepted. Defaults to `false`.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"features": featuresBlock(),
+		},
 	}
 }
 
 // providerConfig holds the configuration data.
 type providerConfig struct {
-	AdminAPIKey types.String `tfsdk:"admin_api_key"`
-	BaseURL     types.String `tfsdk:"base_url"`
+	AdminAPIKey         types.String `tfsdk:"admin_api_key"`
+	AdminAPIKeyFile     types.String `tfsdk:"admin_api_key_file"`
+	APIKeyCommand       types.List   `tfsdk:"api_key_command"`
+	PublicKey           types.String `tfsdk:"public_key"`
+	SecretKey           types.String `tfsdk:"secret_key"`
+	BaseURL             types.String `tfsdk:"base_url"`
+	AllowInsecureHTTP   types.Bool   `tfsdk:"allow_insecure_http"`
+	HostHeader          types.String `tfsdk:"host_header"`
+	DialAddress         types.String `tfsdk:"dial_address"`
+	KeepAlive           types.String `tfsdk:"keep_alive"`
+	DisableKeepAlives   types.Bool   `tfsdk:"disable_keep_alives"`
+	MaxIdleConnsPerHost types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	IdleConnTimeout     types.String `tfsdk:"idle_conn_timeout"`
+	EnableHTTP2         types.Bool   `tfsdk:"enable_http2"`
+	Compression         types.Bool   `tfsdk:"compression"`
+	MaxRedirects        types.Int64  `tfsdk:"max_redirects"`
+	RequestTimeout      types.String `tfsdk:"request_timeout"`
+	MaxRetries          types.Int64  `tfsdk:"max_retries"`
+	RetryJitter         types.Bool   `tfsdk:"retry_jitter"`
+	RetryMaxElapsedTime types.String `tfsdk:"retry_max_elapsed_time"`
+	MaxConcurrentReqs   types.Int64  `tfsdk:"max_concurrent_requests"`
+	PageSize            types.Int64  `tfsdk:"page_size"`
+	ReadCache           types.Bool   `tfsdk:"read_cache"`
+	ReadAfterCreate     types.Bool   `tfsdk:"read_after_create"`
+	UnknownFields       types.String `tfsdk:"unknown_response_fields"`
+	OTelTracing         types.Bool   `tfsdk:"otel_tracing"`
+	OTelEndpoint        types.String `tfsdk:"otel_endpoint"`
+	OTelHeaders         types.Map    `tfsdk:"otel_headers"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
+
+	// Features is nil when the features block is absent.
+	Features *featuresConfig `tfsdk:"features"`
+}
+
+// ConfigValidators returns the cross-attribute rules of the provider configuration.
+func (p *LangfuseProvider) ConfigValidators(ctx context.Context) []provider.ConfigValidator {
+	return []provider.ConfigValidator{
+		conflicting("admin_api_key", "admin_api_key_file", "api_key_command"),
+		requiredTogether("public_key", "secret_key"),
+	}
+}
+
+// ValidateConfig checks the provider configuration during validate and plan,
+// so a malformed base_url is reported before any API call is attempted.
+func (p *LangfuseProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var config providerConfig
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.BaseURL.IsNull() || config.BaseURL.IsUnknown() || config.AllowInsecureHTTP.IsUnknown() {
+		return
+	}
+	if _, err := normalizeBaseURL(config.BaseURL.ValueString(), config.AllowInsecureHTTP.ValueBool()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base URL", err.Error())
+	}
+}
+
+// normalizeBaseURL validates raw as a Langfuse base URL and returns it without
+// trailing slashes. Plain http is rejected for non-local hosts unless
+// allowInsecure is set.
+func normalizeBaseURL(raw string, allowInsecure bool) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("`base_url` %q is not a valid URL: %s", raw, err)
+	}
+	if u.Scheme == "unix" {
+		if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			return "", fmt.Errorf("`base_url` %q must have the form unix:///absolute/path/to/socket", raw)
+		}
+		return "unix://" + u.Path, nil
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("`base_url` %q must use the http, https or unix scheme", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("`base_url` %q must include a host", raw)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("`base_url` %q must not contain credentials, a query string or a fragment", raw)
+	}
+	if u.Scheme == "http" && !allowInsecure && !isLocalHost(u.Hostname()) {
+		return "", fmt.Errorf("`base_url` %q uses plain http for a non-local host; use https or set `allow_insecure_http = true`", raw)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// isLocalHost reports whether host refers to the local machine.
+func isLocalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
 
 // Configure initializes the Langfuse API client using the provider config.
@@ -58,26 +299,389 @@ func (p *LangfuseProvider) Configure(ctx context.Context, req provider.Configure
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if config.AdminAPIKey.IsUnknown() || config.AdminAPIKey.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing Admin API key",
-			"The provider requires `admin_api_key` to be configured.",
-		)
+
+	// Connection settings may depend on values that are only known after apply,
+	// e.g. a key created by another resource. Defer the dependent resources to
+	// a later round when Terraform supports it instead of failing the plan.
+	if unknown := config.unknownConnectionAttributes(); len(unknown) > 0 {
+		if req.ClientCapabilities.DeferralAllowed {
+			resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+			return
+		}
+		for _, attr := range unknown {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr),
+				"Unknown provider configuration value",
+				fmt.Sprintf("`%s` is not known until apply. Either set it to a value known during plan, or use a Terraform version that supports deferred changes.", attr),
+			)
+		}
+		return
+	}
+
+	adminAPIKey, diags := resolveAdminAPIKey(ctx, config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Default base_url if not set
-	baseURL := "http://localhost:3000"
+	baseURL := defaultBaseURL
 	if !config.BaseURL.IsNull() && !config.BaseURL.IsUnknown() {
-		baseURL = config.BaseURL.ValueString()
+		normalized, err := normalizeBaseURL(config.BaseURL.ValueString(), config.AllowInsecureHTTP.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base URL", err.Error())
+			return
+		}
+		baseURL = normalized
+	}
+
+	var opts []client.Option
+	if config.PublicKey.IsNull() != config.SecretKey.IsNull() {
+		resp.Diagnostics.AddError(
+			"Incomplete public API credentials",
+			"`public_key` and `secret_key` must be configured together.",
+		)
+		return
+	}
+	if !config.PublicKey.IsNull() {
+		opts = append(opts, client.WithPublicAPICredentials(config.PublicKey.ValueString(), config.SecretKey.ValueString()))
+	}
+
+	if !config.PageSize.IsNull() && !config.PageSize.IsUnknown() {
+		if config.PageSize.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("page_size"),
+				"Invalid page size",
+				"`page_size` must be at least 1.",
+			)
+			return
+		}
+		opts = append(opts, client.WithPageSize(int(config.PageSize.ValueInt64())))
+	}
+
+	if !config.MaxConcurrentReqs.IsNull() && !config.MaxConcurrentReqs.IsUnknown() {
+		if config.MaxConcurrentReqs.ValueInt64() < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_concurrent_requests"),
+				"Invalid concurrency limit",
+				"`max_concurrent_requests` must not be negative.",
+			)
+			return
+		}
+		opts = append(opts, client.WithMaxConcurrentRequests(int(config.MaxConcurrentReqs.ValueInt64())))
+	}
+
+	if !config.HostHeader.IsNull() && !config.HostHeader.IsUnknown() {
+		opts = append(opts, client.WithHostHeader(config.HostHeader.ValueString()))
+	}
+	if !config.DialAddress.IsNull() && !config.DialAddress.IsUnknown() {
+		if strings.HasPrefix(baseURL, "unix://") {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dial_address"),
+				"Conflicting connection settings",
+				"`dial_address` cannot be combined with a unix:// `base_url`.",
+			)
+			return
+		}
+		opts = append(opts, client.WithDialAddress(config.DialAddress.ValueString()))
+	}
+
+	resp.Diagnostics.Append(transportOptions(config, &opts)...)
+	resp.Diagnostics.Append(retryOptions(config, &opts)...)
+	if !config.UnknownFields.IsNull() && !config.UnknownFields.IsUnknown() {
+		switch config.UnknownFields.ValueString() {
+		case "ignore":
+			opts = append(opts, client.WithUnknownFieldMode(client.UnknownFieldsIgnore))
+		case "warn":
+			opts = append(opts, client.WithUnknownFieldMode(client.UnknownFieldsWarn))
+		case "error":
+			opts = append(opts, client.WithUnknownFieldMode(client.UnknownFieldsFail))
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("unknown_response_fields"),
+				"Invalid unknown field handling",
+				fmt.Sprintf("`unknown_response_fields` must be one of `ignore`, `warn` or `error`, got %q.", config.UnknownFields.ValueString()),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.OTelTracing.ValueBool() {
+		headers := map[string]string{}
+		resp.Diagnostics.Append(config.OTelHeaders.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tp, err := newTracerProvider(ctx, p.version, config.OTelEndpoint.ValueString(), headers)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to configure OpenTelemetry tracing", err.Error())
+			return
+		}
+		opts = append(opts, client.WithHooks(client.NewTracingHook(tp)))
 	}
 
 	// Create the Langfuse API client with the provided settings.
-	c := client.NewClient(baseURL, config.AdminAPIKey.ValueString())
+	c := client.NewClient(baseURL, adminAPIKey, opts...)
+
+	// Optionally verify connectivity and credentials up front, so a wrong key or
+	// URL is reported here rather than on the first resource operation.
+	if config.ValidateCredentials.ValueBool() {
+		if _, err := c.ListOrganizations(ctx); err != nil {
+			addError(
+				&resp.Diagnostics,
+				"Unable to authenticate with Langfuse",
+				fmt.Sprintf("Listing organizations at %s failed. Check that `base_url` points at the Langfuse instance and that the Admin API key is valid.\n\n%s", baseURL, err),
+			)
+			return
+		}
+	}
+
+	var api client.LangfuseAPI = c
+	if config.ReadCache.IsNull() || config.ReadCache.ValueBool() {
+		api = client.NewCachedAPI(c)
+	}
 
 	// Pass the client to all resources and data sources
-	resp.ResourceData = c
-	resp.DataSourceData = c
+	resp.ResourceData = &resourceData{
+		client:          api,
+		readAfterCreate: config.ReadAfterCreate.IsNull() || config.ReadAfterCreate.ValueBool(),
+		features:        config.Features.resolve(),
+	}
+	resp.ListResourceData = resp.ResourceData
+	resp.DataSourceData = api
+	resp.EphemeralResourceData = api
+	resp.ActionData = api
+}
+
+// resourceData is passed from the provider to resources: the API client along
+// with the provider settings that change how resources operate.
+type resourceData struct {
+	client client.LangfuseAPI
+	// readAfterCreate makes Create wait until the new object can be read.
+	readAfterCreate bool
+	// features holds the experimental features enabled in the features block.
+	features features
+}
+
+// unknownConnectionAttributes returns the names of settings needed to reach the
+// API whose values are not yet known.
+func (c providerConfig) unknownConnectionAttributes() []string {
+	var unknown []string
+	attrs := map[string]attr.Value{
+		"admin_api_key":      c.AdminAPIKey,
+		"admin_api_key_file": c.AdminAPIKeyFile,
+		"api_key_command":    c.APIKeyCommand,
+		"public_key":         c.PublicKey,
+		"secret_key":         c.SecretKey,
+		"base_url":           c.BaseURL,
+		"host_header":        c.HostHeader,
+		"dial_address":       c.DialAddress,
+	}
+	for name, v := range attrs {
+		if v.IsUnknown() {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// resolveAdminAPIKey returns the Admin API key from admin_api_key, the file
+// referenced by admin_api_key_file, or the output of api_key_command.
+func resolveAdminAPIKey(ctx context.Context, config providerConfig) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	hasKey := !config.AdminAPIKey.IsNull() && !config.AdminAPIKey.IsUnknown()
+	hasFile := !config.AdminAPIKeyFile.IsNull() && !config.AdminAPIKeyFile.IsUnknown()
+	hasCommand := !config.APIKeyCommand.IsNull() && !config.APIKeyCommand.IsUnknown()
+
+	sources := 0
+	for _, set := range []bool{hasKey, hasFile, hasCommand} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		diags.AddError(
+			"Conflicting Admin API key settings",
+			"Only one of `admin_api_key`, `admin_api_key_file` and `api_key_command` may be configured.",
+		)
+		return "", diags
+	}
+
+	switch {
+	case hasKey:
+		return config.AdminAPIKey.ValueString(), diags
+	case hasFile:
+		keyPath := config.AdminAPIKeyFile.ValueString()
+		data, err := os.ReadFile(keyPath)
+		if err != nil {
+			diags.AddError(
+				"Unable to read Admin API key file",
+				fmt.Sprintf("Reading `admin_api_key_file` %q failed: %s", keyPath, err),
+			)
+			return "", diags
+		}
+		key := strings.TrimSpace(string(data))
+		if key == "" {
+			diags.AddError(
+				"Empty Admin API key file",
+				fmt.Sprintf("The file %q referenced by `admin_api_key_file` is empty.", keyPath),
+			)
+			return "", diags
+		}
+		return key, diags
+	case hasCommand:
+		var args []string
+		diags.Append(config.APIKeyCommand.ElementsAs(ctx, &args, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+		return runAPIKeyCommand(ctx, args)
+	}
+
+	diags.AddError(
+		"Missing Admin API key",
+		"The provider requires one of `admin_api_key`, `admin_api_key_file` or `api_key_command` to be configured.",
+	)
+	return "", diags
+}
+
+// runAPIKeyCommand executes the credential helper and returns its trimmed stdout.
+func runAPIKeyCommand(ctx context.Context, args []string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if len(args) == 0 || args[0] == "" {
+		diags.AddAttributeError(
+			path.Root("api_key_command"),
+			"Invalid API key command",
+			"`api_key_command` must contain at least the program to execute.",
+		)
+		return "", diags
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		diags.AddError(
+			"API key command failed",
+			fmt.Sprintf("Running `api_key_command` (%s) failed: %s\n\n%s", args[0], err, strings.TrimSpace(stderr.String())),
+		)
+		return "", diags
+	}
+
+	key := strings.TrimSpace(stdout.String())
+	if key == "" {
+		diags.AddError(
+			"Empty API key command output",
+			fmt.Sprintf("`api_key_command` (%s) did not print an API key to standard output.", args[0]),
+		)
+		return "", diags
+	}
+	return key, diags
+}
+
+// transportOptions appends client options for the HTTP transport tuning settings.
+func transportOptions(config providerConfig, opts *[]client.Option) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !config.KeepAlive.IsNull() && !config.KeepAlive.IsUnknown() {
+		d, err := time.ParseDuration(config.KeepAlive.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("keep_alive"), "Invalid duration", err.Error())
+		} else {
+			*opts = append(*opts, client.WithKeepAlive(d))
+		}
+	}
+	if !config.DisableKeepAlives.IsNull() && !config.DisableKeepAlives.IsUnknown() {
+		*opts = append(*opts, client.WithDisableKeepAlives(config.DisableKeepAlives.ValueBool()))
+	}
+	if !config.MaxIdleConnsPerHost.IsNull() && !config.MaxIdleConnsPerHost.IsUnknown() {
+		if config.MaxIdleConnsPerHost.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("max_idle_conns_per_host"),
+				"Invalid idle connection limit",
+				"`max_idle_conns_per_host` must not be negative.",
+			)
+		} else {
+			*opts = append(*opts, client.WithMaxIdleConnsPerHost(int(config.MaxIdleConnsPerHost.ValueInt64())))
+		}
+	}
+	if !config.IdleConnTimeout.IsNull() && !config.IdleConnTimeout.IsUnknown() {
+		d, err := time.ParseDuration(config.IdleConnTimeout.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("idle_conn_timeout"), "Invalid duration", err.Error())
+		} else {
+			*opts = append(*opts, client.WithIdleConnTimeout(d))
+		}
+	}
+	if !config.EnableHTTP2.IsNull() && !config.EnableHTTP2.IsUnknown() {
+		*opts = append(*opts, client.WithHTTP2(config.EnableHTTP2.ValueBool()))
+	}
+	if !config.Compression.IsNull() && !config.Compression.IsUnknown() {
+		*opts = append(*opts, client.WithCompression(config.Compression.ValueBool()))
+	}
+	if !config.MaxRedirects.IsNull() && !config.MaxRedirects.IsUnknown() {
+		if config.MaxRedirects.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("max_redirects"),
+				"Invalid redirect limit",
+				"`max_redirects` must not be negative.",
+			)
+		} else {
+			*opts = append(*opts, client.WithMaxRedirects(int(config.MaxRedirects.ValueInt64())))
+		}
+	}
+
+	return diags
+}
+
+// retryOptions appends client options for the request timeout and retry settings.
+func retryOptions(config providerConfig, opts *[]client.Option) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	policy := client.DefaultRetryPolicy
+	changed := false
+	if !config.RequestTimeout.IsNull() && !config.RequestTimeout.IsUnknown() {
+		d, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || d < 0 {
+			diags.AddAttributeError(path.Root("request_timeout"), "Invalid duration", fmt.Sprintf("`request_timeout` must be a non-negative Go duration, got %q.", config.RequestTimeout.ValueString()))
+		} else {
+			*opts = append(*opts, client.WithRequestTimeout(d))
+		}
+	}
+	if !config.MaxRetries.IsNull() && !config.MaxRetries.IsUnknown() {
+		if config.MaxRetries.ValueInt64() < 0 {
+			diags.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid retry count",
+				"`max_retries` must not be negative.",
+			)
+		}
+		policy.MaxRetries = int(config.MaxRetries.ValueInt64())
+		changed = true
+	}
+	if !config.RetryJitter.IsNull() && !config.RetryJitter.IsUnknown() {
+		policy.Jitter = config.RetryJitter.ValueBool()
+		changed = true
+	}
+	if !config.RetryMaxElapsedTime.IsNull() && !config.RetryMaxElapsedTime.IsUnknown() {
+		d, err := time.ParseDuration(config.RetryMaxElapsedTime.ValueString())
+		if err != nil || d <= 0 {
+			diags.AddAttributeError(path.Root("retry_max_elapsed_time"), "Invalid duration", fmt.Sprintf("`retry_max_elapsed_time` must be a positive Go duration, got %q.", config.RetryMaxElapsedTime.ValueString()))
+		} else {
+			policy.MaxElapsedTime = d
+			changed = true
+		}
+	}
+
+	if changed && !diags.HasError() {
+		*opts = append(*opts, client.WithRetryPolicy(policy))
+	}
+	return diags
 }
 
 // Resources returns a list of resource constructors.
@@ -85,10 +689,52 @@ func (p *LangfuseProvider) Resources(ctx context.Context) []func() resource.Reso
 	return []func() resource.Resource{
 		NewOrganizationResource,
 		NewProjectResource,
+		NewAPIKeyResource,
 	}
 }
 
-// DataSources returns a list of data source constructors (none in this provider).
+// DataSources returns a list of data source constructors.
 func (p *LangfuseProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewDatasetRunItemsDataSource,
+		NewAnnotationQueueItemsDataSource,
+		NewAPIKeyDataSource,
+		NewAPIKeysDataSource,
+	}
+}
+
+// ListResources returns a list of list resource constructors, which let
+// terraform query enumerate objects of the managed resource types.
+func (p *LangfuseProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewOrganizationListResource,
+		NewProjectListResource,
+	}
+}
+
+// Actions returns a list of action constructors, which run day-2
+// operations that do not correspond to managed objects.
+func (p *LangfuseProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPromotePromptAction,
+		NewRotateProjectKeysAction,
+	}
+}
+
+// EphemeralResources returns a list of ephemeral resource constructors.
+func (p *LangfuseProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPromptEphemeralResource,
+	}
+}
+
+// Functions returns a list of provider-defined function constructors.
+func (p *LangfuseProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewImportIDFunction,
+		NewValidatePromptTemplateFunction,
+		NewPromptReferenceFunction,
+		NewModelPatternMatchesFunction,
+		NewKeyFingerprintFunction,
+	}
 }
@@ -0,0 +1,396 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiKeyResource implements the langfuse_api_key resource.
+type apiKeyResource struct {
+	client client.LangfuseAPI
+}
+
+// NewAPIKeyResource returns a new apiKeyResource.
+func NewAPIKeyResource() resource.Resource {
+	return &apiKeyResource{}
+}
+
+var (
+	_ resource.ResourceWithImportState = &apiKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &apiKeyResource{}
+)
+
+// Metadata sets the resource type name.
+func (r *apiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_key"
+}
+
+// Schema defines the schema for API keys.
+func (r *apiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resource for managing an additional API key pair of a Langfuse project. Destroying the resource revokes the key. Keys cannot be changed in place: any change of the configured attributes replaces the key, so set `create_before_destroy` in a `lifecycle` block to have the new key exist before the old one is revoked.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the API key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the organization owning the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project the key grants access to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"note": schema.StringAttribute{
+				Optional:    true,
+				Description: "Note documenting the purpose of the key, e.g. the service and environment using it. Changing it replaces the key, as Langfuse cannot edit notes.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_trigger": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that replace the key pair whenever they change, like the `keepers` of the random provider: a new key is generated and the old one revoked. Set it to e.g. a rotation date or version string to rotate keys on a schedule.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_age": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{positiveDuration()},
+				Description: "Maximum lifetime of the key as a duration, e.g. `\"720h\"`. Langfuse cannot expire keys created through its API, so the provider enforces it: `expires_at` is set to the creation time plus `max_age`, and once that time has passed the next plan replaces the key, creating a new key pair and revoking the old one.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the key expires, in RFC 3339 format: the expiry reported by Langfuse if it has one, otherwise the creation time plus `max_age`. Null for keys that do not expire.",
+			},
+			"public_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "Public key, `pk-lf-...`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_key": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Secret key, `sk-lf-...`. Langfuse only returns it when the key is created, so it is null for imported keys.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_secret_key": schema.StringAttribute{
+				Computed:    true,
+				Description: "Shortened form of the secret key, e.g. `sk-lf-...abcd`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the key was created, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// apiKeyResourceModel maps the API key resource schema.
+type apiKeyResourceModel struct {
+	ID               types.String   `tfsdk:"id"`
+	OrganizationID   types.String   `tfsdk:"organization_id"`
+	ProjectID        types.String   `tfsdk:"project_id"`
+	Note             types.String   `tfsdk:"note"`
+	RotationTrigger  types.Map      `tfsdk:"rotation_trigger"`
+	MaxAge           types.String   `tfsdk:"max_age"`
+	ExpiresAt        types.String   `tfsdk:"expires_at"`
+	PublicKey        types.String   `tfsdk:"public_key"`
+	SecretKey        types.String   `tfsdk:"secret_key"`
+	DisplaySecretKey types.String   `tfsdk:"display_secret_key"`
+	CreatedAt        types.String   `tfsdk:"created_at"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure injects the Langfuse client.
+func (r *apiKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resourceData, got %T", req.ProviderData),
+		)
+		return
+	}
+	r.client = data.client
+}
+
+// Create generates a new key pair for the project.
+func (r *apiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logClientMetrics(ctx, r.client)
+
+	var plan apiKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	key, err := r.client.CreateProjectAPIKey(ctx, plan.OrganizationID.ValueString(), plan.ProjectID.ValueString(), client.APIKeyCreate{
+		Note: plan.Note.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error creating API key", err)
+		return
+	}
+
+	plan.ID = types.StringValue(key.ID)
+	plan.PublicKey = types.StringValue(key.PublicKey)
+	plan.SecretKey = types.StringValue(key.SecretKey)
+	plan.DisplaySecretKey = types.StringValue(key.DisplaySecretKey)
+	plan.CreatedAt = types.StringValue(key.CreatedAt)
+	plan.ExpiresAt, diags = keyExpiresAt(key, plan.MaxAge)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the key from the API keys of the project.
+func (r *apiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logClientMetrics(ctx, r.client)
+
+	var state apiKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	keys, err := r.client.ListProjectAPIKeys(ctx, state.OrganizationID.ValueString(), state.ProjectID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "Error reading API key", err)
+		return
+	}
+	var key *client.APIKey
+	for i := range keys {
+		if keys[i].ID == state.ID.ValueString() {
+			key = &keys[i]
+		}
+	}
+	if key == nil {
+		// Revoked, or its project deleted, outside of Terraform.
+		tflog.Warn(ctx, "API key not found, removing it from state", map[string]interface{}{
+			"project_id": state.ProjectID.ValueString(),
+			"id":         state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.PublicKey = types.StringValue(key.PublicKey)
+	state.DisplaySecretKey = types.StringValue(key.DisplaySecretKey)
+	state.Note = optionalString(key.Note)
+	state.CreatedAt = types.StringValue(key.CreatedAt)
+	state.ExpiresAt, diags = keyExpiresAt(key, state.MaxAge)
+	resp.Diagnostics.Append(diags...)
+	// The secret key is not returned by the list; keep the state value.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update stores a changed max_age or timeouts. Every other change replaces
+// the key and Langfuse cannot edit keys, so only expires_at is recomputed.
+func (r *apiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer logClientMetrics(ctx, r.client)
+
+	var plan, state apiKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SecretKey = state.SecretKey
+	if plan.ExpiresAt.IsUnknown() {
+		keys, err := r.client.ListProjectAPIKeys(ctx, plan.OrganizationID.ValueString(), plan.ProjectID.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Error reading API key", err)
+			return
+		}
+		key := &client.APIKey{CreatedAt: state.CreatedAt.ValueString()}
+		for i := range keys {
+			if keys[i].ID == state.ID.ValueString() {
+				key = &keys[i]
+			}
+		}
+		var diags diag.Diagnostics
+		plan.ExpiresAt, diags = keyExpiresAt(key, plan.MaxAge)
+		resp.Diagnostics.Append(diags...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// ModifyPlan replaces keys that have expired, and recomputes expires_at when
+// max_age changes. A replaced key gets new values for every computed
+// attribute, so they are unknown until apply.
+func (r *apiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var plan, state apiKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if keyExpired(state.ExpiresAt, time.Now()) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("expires_at"))
+	}
+	if len(resp.RequiresReplace) > 0 {
+		// The replacement is a new key pair.
+		for _, name := range []string{"id", "public_key", "secret_key", "display_secret_key", "created_at", "expires_at"} {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), types.StringUnknown())...)
+		}
+		return
+	}
+	expiresAt := state.ExpiresAt
+	if !plan.MaxAge.Equal(state.MaxAge) {
+		expiresAt = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("expires_at"), expiresAt)...)
+}
+
+// keyExpiresAt returns the expiry of key: the one reported by Langfuse, if
+// any, otherwise its creation time plus maxAge.
+func keyExpiresAt(key *client.APIKey, maxAge types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if key.ExpiresAt != "" {
+		return types.StringValue(key.ExpiresAt), diags
+	}
+	if maxAge.IsNull() || maxAge.IsUnknown() {
+		return types.StringNull(), diags
+	}
+	d, err := time.ParseDuration(maxAge.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("max_age"), "Invalid duration", err.Error())
+		return types.StringNull(), diags
+	}
+	created, err := time.Parse(time.RFC3339, key.CreatedAt)
+	if err != nil {
+		diags.AddError("Unable to compute key expiry", fmt.Sprintf("Langfuse returned creation time %q, which is not in RFC 3339 format.", key.CreatedAt))
+		return types.StringNull(), diags
+	}
+	return types.StringValue(created.Add(d).UTC().Format(time.RFC3339)), diags
+}
+
+// keyExpired reports whether expiresAt lies before now. Unset and unparsable
+// expiries never expire.
+func keyExpired(expiresAt types.String, now time.Time) bool {
+	if expiresAt.IsNull() || expiresAt.IsUnknown() {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt.ValueString())
+	return err == nil && !now.Before(t)
+}
+
+// Delete revokes the key.
+func (r *apiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logClientMetrics(ctx, r.client)
+
+	var state apiKeyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteProjectAPIKey(ctx, state.OrganizationID.ValueString(), state.ProjectID.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "Error revoking API key", err)
+	}
+}
+
+// ImportState imports an existing key by "orgID/projectID/keyID". The secret
+// key cannot be recovered and stays null.
+func (r *apiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgID, projID, keyID, err := parseAPIKeyImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), types.StringValue(orgID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), types.StringValue(projID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(keyID))...)
+}
+
+// parseAPIKeyImportID splits an API key import ID into its organization,
+// project and key IDs.
+func parseAPIKeyImportID(id string) (orgID, projID, keyID string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		return "", "", "", errors.New("Expected import ID in the form \"<organization_id>/<project_id>/<api_key_id>\" (e.g. \"org123/proj456/key789\").")
+	}
+	for i, name := range []string{"organization_id", "project_id", "api_key_id"} {
+		if err := checkImportIDPart(name, parts[i]); err != nil {
+			return "", "", "", fmt.Errorf("%s in %q.", err, id)
+		}
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,311 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiKeyModel returns an API key resource model for a key of the given
+// project. A nil key yields the unknown values of a plan for a new key.
+func apiKeyModel(orgID, projID string, key *client.APIKey) apiKeyResourceModel {
+	m := apiKeyResourceModel{
+		ID:               types.StringUnknown(),
+		OrganizationID:   types.StringValue(orgID),
+		ProjectID:        types.StringValue(projID),
+		Note:             types.StringNull(),
+		RotationTrigger:  types.MapNull(types.StringType),
+		MaxAge:           types.StringNull(),
+		ExpiresAt:        types.StringUnknown(),
+		PublicKey:        types.StringUnknown(),
+		SecretKey:        types.StringUnknown(),
+		DisplaySecretKey: types.StringUnknown(),
+		CreatedAt:        types.StringUnknown(),
+		// The resource has no update timeout, unlike nullTimeouts.
+		Timeouts: timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"delete": types.StringType,
+		})},
+	}
+	if key != nil {
+		m.ID = types.StringValue(key.ID)
+		m.Note = optionalString(key.Note)
+		m.PublicKey = types.StringValue(key.PublicKey)
+		m.SecretKey = types.StringValue(key.SecretKey)
+		m.DisplaySecretKey = types.StringValue(key.DisplaySecretKey)
+		m.CreatedAt = types.StringValue(key.CreatedAt)
+		m.ExpiresAt = optionalString(key.ExpiresAt)
+	}
+	return m
+}
+
+func TestAPIKeyResourceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		projID  string
+		note    string
+		maxAge  string
+		err     error
+		wantErr string
+	}{
+		{name: "success"},
+		{name: "with note", note: "checkout service, production"},
+		{name: "with max_age", maxAge: "720h"},
+		{name: "missing project", projID: "proj-missing", wantErr: "Error creating API key"},
+		{name: "api error", err: errors.New("connection reset"), wantErr: "Error creating API key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+			fake.Err = tt.err
+			projID := proj.ID
+			if tt.projID != "" {
+				projID = tt.projID
+			}
+			r := &apiKeyResource{client: fake}
+			s := resourceSchema(t, r)
+
+			resp := resource.CreateResponse{State: newState(t, s, nil)}
+			plan := apiKeyModel(org.ID, projID, nil)
+			if tt.note != "" {
+				plan.Note = types.StringValue(tt.note)
+			}
+			if tt.maxAge != "" {
+				plan.MaxAge = types.StringValue(tt.maxAge)
+			}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, plan)}, &resp)
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				if !resp.State.Raw.IsNull() {
+					t.Error("state was set despite the error")
+				}
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got apiKeyResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			keys, _ := fake.ListProjectAPIKeys(ctx, org.ID, proj.ID)
+			if len(keys) != 2 || keys[1].ID != got.ID.ValueString() {
+				t.Fatalf("project keys = %+v, want the initial key and %s", keys, got.ID)
+			}
+			if got.PublicKey.ValueString() != keys[1].PublicKey || got.SecretKey.ValueString() == "" {
+				t.Errorf("public_key, secret_key = %s, %s", got.PublicKey, got.SecretKey)
+			}
+			if keys[1].Note != tt.note {
+				t.Errorf("API note = %q, want %q", keys[1].Note, tt.note)
+			}
+			if tt.maxAge == "" {
+				if !got.ExpiresAt.IsNull() {
+					t.Errorf("expires_at = %s, want null", got.ExpiresAt)
+				}
+				return
+			}
+			created, _ := time.Parse(time.RFC3339, got.CreatedAt.ValueString())
+			if want := created.Add(720 * time.Hour).Format(time.RFC3339); got.ExpiresAt.ValueString() != want {
+				t.Errorf("expires_at = %s, want %s", got.ExpiresAt, want)
+			}
+		})
+	}
+}
+
+func TestAPIKeyResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		note        string
+		revoke      bool
+		deleteProj  bool
+		err         error
+		wantRemoved bool
+		wantErr     string
+	}{
+		{name: "unchanged"},
+		{name: "with note", note: "checkout service, production"},
+		{name: "revoked outside of terraform", revoke: true, wantRemoved: true},
+		{name: "project deleted", deleteProj: true, wantRemoved: true},
+		{name: "api error", err: errors.New("internal server error"), wantErr: "Error reading API key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+			key, _ := fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{Note: tt.note})
+			if tt.revoke {
+				fake.DeleteProjectAPIKey(ctx, org.ID, proj.ID, key.ID)
+			}
+			if tt.deleteProj {
+				fake.DeleteProject(ctx, org.ID, proj.ID)
+			}
+			fake.Err = tt.err
+			r := &apiKeyResource{client: fake}
+			s := resourceSchema(t, r)
+
+			state := newState(t, s, apiKeyModel(org.ID, proj.ID, key))
+			resp := resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+			switch {
+			case tt.wantErr != "":
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			case tt.wantRemoved:
+				requireNoErrors(t, resp.Diagnostics)
+				if !resp.State.Raw.IsNull() {
+					t.Error("missing key was not removed from state")
+				}
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got apiKeyResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if got.PublicKey.ValueString() != key.PublicKey || got.DisplaySecretKey.ValueString() != key.DisplaySecretKey {
+				t.Errorf("public_key, display_secret_key = %s, %s", got.PublicKey, got.DisplaySecretKey)
+			}
+			if got.Note.ValueString() != tt.note || got.Note.IsNull() != (tt.note == "") {
+				t.Errorf("note = %s, want %q", got.Note, tt.note)
+			}
+			// The API never returns the secret key again.
+			if got.SecretKey.ValueString() != key.SecretKey {
+				t.Errorf("secret_key was not kept from state")
+			}
+		})
+	}
+}
+
+func TestAPIKeyResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	key, _ := fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{})
+	r := &apiKeyResource{client: fake}
+	s := resourceSchema(t, r)
+	state := newState(t, s, apiKeyModel(org.ID, proj.ID, key))
+
+	// Setting max_age gives the existing key an expiry.
+	plan := apiKeyModel(org.ID, proj.ID, key)
+	plan.MaxAge = types.StringValue("24h")
+	plan.ExpiresAt = types.StringUnknown()
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, plan), State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	var got apiKeyResourceModel
+	requireNoErrors(t, resp.State.Get(ctx, &got))
+	created, _ := time.Parse(time.RFC3339, key.CreatedAt)
+	if want := created.Add(24 * time.Hour).Format(time.RFC3339); got.ExpiresAt.ValueString() != want {
+		t.Errorf("expires_at = %s, want %s", got.ExpiresAt, want)
+	}
+	if got.SecretKey.ValueString() != key.SecretKey {
+		t.Errorf("secret_key was not kept from state")
+	}
+}
+
+func TestAPIKeyResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := &apiKeyResource{}
+	s := resourceSchema(t, r)
+	key := &client.APIKey{ID: "key-1", PublicKey: "pk-lf-1", SecretKey: "sk-lf-1", DisplaySecretKey: "sk-lf-...lf-1", CreatedAt: "2025-01-01T00:00:00Z"}
+
+	tests := []struct {
+		name        string
+		expiresAt   string
+		maxAge      string
+		wantReplace bool
+		wantUnknown bool
+	}{
+		{name: "no expiry"},
+		{name: "not expired yet", expiresAt: "2999-01-01T00:00:00Z"},
+		{name: "expired", expiresAt: "2025-01-31T00:00:00Z", wantReplace: true, wantUnknown: true},
+		{name: "max_age changed", expiresAt: "2999-01-01T00:00:00Z", maxAge: "48h", wantUnknown: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prior := apiKeyModel("org-1", "proj-1", key)
+			prior.ExpiresAt = optionalString(tt.expiresAt)
+			proposed := prior
+			if tt.maxAge != "" {
+				proposed.MaxAge = types.StringValue(tt.maxAge)
+			}
+			plan := newPlan(t, s, proposed)
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: newState(t, s, prior)}, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			if got := len(resp.RequiresReplace) > 0; got != tt.wantReplace {
+				t.Errorf("requires replace = %t, want %t", got, tt.wantReplace)
+			}
+			var got apiKeyResourceModel
+			requireNoErrors(t, resp.Plan.Get(ctx, &got))
+			if got.ExpiresAt.IsUnknown() != tt.wantUnknown {
+				t.Errorf("planned expires_at = %s, want unknown %t", got.ExpiresAt, tt.wantUnknown)
+			}
+			if got.ID.IsUnknown() != tt.wantReplace {
+				t.Errorf("planned id = %s, want unknown %t", got.ID, tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestAPIKeyResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	key, _ := fake.CreateProjectAPIKey(ctx, org.ID, proj.ID, client.APIKeyCreate{})
+	r := &apiKeyResource{client: fake}
+	s := resourceSchema(t, r)
+	state := newState(t, s, apiKeyModel(org.ID, proj.ID, key))
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	keys, _ := fake.ListProjectAPIKeys(ctx, org.ID, proj.ID)
+	for _, k := range keys {
+		if k.ID == key.ID {
+			t.Errorf("key %s was not revoked", key.ID)
+		}
+	}
+
+	// Keys already revoked are gone either way.
+	resp = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+
+	fake.Err = errors.New("connection reset")
+	resp = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireError(t, resp.Diagnostics, "Error revoking API key")
+}
+
+func TestAPIKeyResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &apiKeyResource{}
+	s := resourceSchema(t, r)
+
+	resp := resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "org-1/proj-1/key-1"}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	var got apiKeyResourceModel
+	requireNoErrors(t, resp.State.Get(ctx, &got))
+	if got.OrganizationID.ValueString() != "org-1" || got.ProjectID.ValueString() != "proj-1" || got.ID.ValueString() != "key-1" {
+		t.Errorf("imported IDs = %s, %s, %s", got.OrganizationID, got.ProjectID, got.ID)
+	}
+
+	resp = resource.ImportStateResponse{State: newState(t, s, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "proj-1/key-1"}, &resp)
+	requireError(t, resp.Diagnostics, "Expected import ID in the form")
+}
@@ -2,18 +2,29 @@ package langfuse
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // organizationResource implements the langfuse_organization resource.
 type organizationResource struct {
-	client *client.Client
+	client client.LangfuseAPI
+	// skipReadAfterCreate disables waiting for created objects to be readable.
+	skipReadAfterCreate bool
+	// deleteProjects makes Delete remove the organization's projects first.
+	deleteProjects bool
 }
 
 // NewOrganizationResource returns a new organizationResource.
@@ -21,6 +32,11 @@ func NewOrganizationResource() resource.Resource {
 	return &organizationResource{}
 }
 
+var (
+	_ resource.ResourceWithUpgradeState = &organizationResource{}
+	_ resource.ResourceWithIdentity     = &organizationResource{}
+)
+
 // Metadata sets the resource type name.
 func (r *organizationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "langfuse_organization"
@@ -29,6 +45,7 @@ func (r *organizationResource) Metadata(ctx context.Context, req resource.Metada
 // Schema defines the schema for organizations.
 func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     organizationSchemaVersion,
 		Description: "Resource for managing Langfuse organizations (self-hosted).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -37,16 +54,49 @@ func (r *organizationResource) Schema(ctx context.Context, req resource.SchemaRe
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "Name of the organization.",
+				Description: "Name of the organization. Must be 3 to 60 characters long.",
+				Validators:  []validator.String{validName()},
+			},
+			"ignore_name_case": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether `name` is compared case-insensitively with the name stored by Langfuse, so a name that differs only in case is not reported as a change. Surrounding whitespace is always ignored. Defaults to false.",
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
 // organizationResourceModel maps schema attributes to Go types.
 type organizationResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID         types.String   `tfsdk:"id"`
+	Name       types.String   `tfsdk:"name"`
+	IgnoreCase types.Bool     `tfsdk:"ignore_name_case"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// organizationIdentityModel maps the identity schema of organizations.
+type organizationIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// IdentitySchema defines the identity of organizations, used to import them
+// with an identity block and to match results of terraform query.
+func (r *organizationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the organization.",
+			},
+		},
+	}
 }
 
 // Configure injects the Langfuse client from the provider.
@@ -54,19 +104,23 @@ func (r *organizationResource) Configure(ctx context.Context, req resource.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	clientData, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got %T", req.ProviderData),
 		)
 		return
 	}
-	r.client = clientData
+	r.client = data.client
+	r.skipReadAfterCreate = !data.readAfterCreate
+	r.deleteProjects = data.features.deleteProjectsOnDestroy
 }
 
 // Create creates a new organization via the API.
 func (r *organizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var plan organizationResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -74,21 +128,45 @@ func (r *organizationResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Call API to create organization
 	org, err := r.client.CreateOrganization(ctx, plan.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating organization", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating organization", err)
 		return
 	}
 
 	// Set state with returned values
 	plan.ID = types.StringValue(org.ID)
-	plan.Name = types.StringValue(org.Name)
+	plan.Name = remoteName(plan.Name, org.Name, plan.IgnoreCase)
+
+	if !r.skipReadAfterCreate {
+		err = waitForVisibility(ctx, "organization "+org.ID, func(ctx context.Context) error {
+			_, err := r.client.GetOrganization(ctx, org.ID)
+			return err
+		})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Organization not yet readable",
+				fmt.Sprintf("Organization %s was created but could not be read back yet: %s\n\nThe next refresh may report it as missing if it does not become visible.", org.ID, err),
+			)
+		}
+	}
 	resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, organizationIdentityModel{ID: plan.ID})...)
 }
 
 // Read refreshes the state by reading from the API.
 func (r *organizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var state organizationResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -96,38 +174,75 @@ func (r *organizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	org, err := r.client.GetOrganization(ctx, state.ID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform: drop it so the next apply recreates it.
+		tflog.Warn(ctx, "Organization not found, removing it from state", map[string]interface{}{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading organization", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading organization", err)
 		return
 	}
 
 	// Update state
-	state.Name = types.StringValue(org.Name)
+	state.Name = remoteName(state.Name, org.Name, state.IgnoreCase)
 	resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, organizationIdentityModel{ID: state.ID})...)
 }
 
-// Update renames the organization via the API.
+// Update sends the changed organization attributes to the API.
 func (r *organizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan organizationResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	defer logClientMetrics(ctx, r.client)
+
+	var plan, state organizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.UpdateOrganization(ctx, plan.ID.ValueString(), plan.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating organization", err.Error())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Only send attributes that changed, so fields not managed here are kept.
+	var update client.OrganizationUpdate
+	if !plan.Name.Equal(state.Name) {
+		update.Name = plan.Name.ValueStringPointer()
+	}
+	if !update.IsEmpty() {
+		_, err := r.client.UpdateOrganization(ctx, plan.ID.ValueString(), update)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Error updating organization", err)
+			return
+		}
+	}
 
 	// Use plan values as new state
 	resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, organizationIdentityModel{ID: plan.ID})...)
 }
 
 // Delete removes the organization via the API.
 func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var state organizationResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -135,13 +250,126 @@ func (r *organizationResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if r.deleteProjects {
+		resp.Diagnostics.Append(r.deleteProjectsOf(ctx, state.ID.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	if err := r.client.DeleteOrganization(ctx, state.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting organization", err.Error())
+		addClientError(&resp.Diagnostics, "Error deleting organization", err)
 	}
 }
 
-// ImportState allows importing an existing organization by ID.
+// deleteProjectsOf deletes all projects of organization orgID, as enabled by
+// the delete_projects_on_destroy feature.
+func (r *organizationResource) deleteProjectsOf(ctx context.Context, orgID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	projects, err := r.client.ListProjects(client.WithoutCache(ctx), orgID)
+	if errors.Is(err, client.ErrNotFound) {
+		return diags
+	}
+	if err != nil {
+		addClientError(&diags, fmt.Sprintf("Error listing the projects of organization %s", orgID), err)
+		return diags
+	}
+	for _, proj := range projects {
+		tflog.Warn(ctx, "Deleting project of destroyed organization", map[string]interface{}{
+			"organization_id": orgID,
+			"project_id":      proj.ID,
+			"project_name":    proj.Name,
+		})
+		err := r.client.DeleteProject(ctx, orgID, proj.ID)
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			addClientError(&diags, fmt.Sprintf("Error deleting project %s of organization %s", proj.ID, orgID), err)
+			return diags
+		}
+	}
+	return diags
+}
+
+// ImportState allows importing an existing organization by ID, or by name
+// with an identifier of the form "name:<organization name>", as well as by
+// identity.
 func (r *organizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// The import identifier is the organization ID.
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(req.ID))...)
+	if req.ID == "" {
+		var identity organizationIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		return
+	}
+
+	id, name, err := parseOrganizationImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	if name != "" {
+		var diags diag.Diagnostics
+		id, diags = r.organizationIDByName(ctx, name)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(id))...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, organizationIdentityModel{ID: types.StringValue(id)})...)
+}
+
+// parseOrganizationImportID returns the organization ID, or the organization
+// name for identifiers of the form "name:<organization name>".
+func parseOrganizationImportID(importID string) (id, name string, err error) {
+	if name, ok := strings.CutPrefix(importID, "name:"); ok {
+		if name == "" {
+			return "", "", errors.New("Expected an organization name after \"name:\".")
+		}
+		return "", name, nil
+	}
+	if err := checkImportIDPart("organization ID", importID); err != nil {
+		return "", "", fmt.Errorf("%s; expected an organization ID or \"name:<organization name>\".", err)
+	}
+	return importID, "", nil
+}
+
+// organizationIDByName returns the ID of the only organization named name.
+func (r *organizationResource) organizationIDByName(ctx context.Context, name string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	orgs, err := r.client.ListOrganizations(ctx)
+	if err != nil {
+		addClientError(&diags, "Error listing organizations", err)
+		return "", diags
+	}
+
+	var ids []string
+	for _, org := range orgs {
+		if org.Name == name {
+			ids = append(ids, org.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		diags.AddError(
+			"Organization not found",
+			fmt.Sprintf("No organization is named %q. Names are matched exactly, including case.", name),
+		)
+	case 1:
+		return ids[0], diags
+	default:
+		diags.AddError(
+			"Ambiguous organization name",
+			fmt.Sprintf("%d organizations are named %q (IDs %s). Import by ID instead.", len(ids), name, strings.Join(ids, ", ")),
+		)
+	}
+	return "", diags
 }
@@ -0,0 +1,261 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// organizationModel returns an organization resource model without timeouts.
+func organizationModel(id, name string) organizationResourceModel {
+	m := organizationResourceModel{
+		ID:       types.StringValue(id),
+		Name:     types.StringValue(name),
+		Timeouts: nullTimeouts(),
+	}
+	if id == "" {
+		m.ID = types.StringUnknown()
+	}
+	return m
+}
+
+func TestOrganizationResourceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		wantErr string
+	}{
+		{name: "success"},
+		{name: "api error", err: errors.New("connection refused"), wantErr: "Error creating organization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			fake.Err = tt.err
+			r := &organizationResource{client: fake}
+			s := resourceSchema(t, r)
+
+			resp := resource.CreateResponse{State: newState(t, s, nil), Identity: newIdentity(t, r)}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, organizationModel("", "team-a"))}, &resp)
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				if !resp.State.Raw.IsNull() {
+					t.Error("state was set despite the error")
+				}
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got organizationResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			org, err := fake.GetOrganization(ctx, got.ID.ValueString())
+			if err != nil {
+				t.Fatalf("organization %s not created: %v", got.ID, err)
+			}
+			if got.Name.ValueString() != "team-a" || org.Name != "team-a" {
+				t.Errorf("name = %s (state), %s (API), want team-a", got.Name, org.Name)
+			}
+		})
+	}
+}
+
+func TestOrganizationResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		rename      string
+		delete      bool
+		err         error
+		wantName    string
+		wantRemoved bool
+		wantErr     string
+	}{
+		{name: "unchanged", wantName: "team-a"},
+		{name: "renamed outside of terraform", rename: "team-b", wantName: "team-b"},
+		{name: "renamed only in whitespace", rename: " team-a ", wantName: "team-a"},
+		{name: "deleted outside of terraform", delete: true, wantRemoved: true},
+		{name: "api error", err: errors.New("internal server error"), wantErr: "Error reading organization"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			if tt.rename != "" {
+				fake.UpdateOrganization(ctx, org.ID, client.OrganizationUpdate{Name: &tt.rename})
+			}
+			if tt.delete {
+				fake.DeleteOrganization(ctx, org.ID)
+			}
+			fake.Err = tt.err
+			r := &organizationResource{client: fake}
+			s := resourceSchema(t, r)
+
+			state := newState(t, s, organizationModel(org.ID, "team-a"))
+			resp := resource.ReadResponse{State: state, Identity: newIdentity(t, r)}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+			switch {
+			case tt.wantErr != "":
+				requireError(t, resp.Diagnostics, tt.wantErr)
+			case tt.wantRemoved:
+				requireNoErrors(t, resp.Diagnostics)
+				if !resp.State.Raw.IsNull() {
+					t.Error("deleted organization was not removed from state")
+				}
+			default:
+				requireNoErrors(t, resp.Diagnostics)
+				var got organizationResourceModel
+				requireNoErrors(t, resp.State.Get(ctx, &got))
+				if got.Name.ValueString() != tt.wantName {
+					t.Errorf("name = %s, want %s", got.Name, tt.wantName)
+				}
+			}
+		})
+	}
+}
+
+func TestOrganizationResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	r := &organizationResource{client: fake}
+	s := resourceSchema(t, r)
+
+	state := newState(t, s, organizationModel(org.ID, "team-a"))
+	resp := resource.UpdateResponse{State: state, Identity: newIdentity(t, r)}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  newPlan(t, s, organizationModel(org.ID, "team-b")),
+		State: state,
+	}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+
+	got, _ := fake.GetOrganization(ctx, org.ID)
+	if got.Name != "team-b" {
+		t.Errorf("API name = %s, want team-b", got.Name)
+	}
+
+	// Updating an organization deleted in the meantime fails.
+	fake.DeleteOrganization(ctx, org.ID)
+	resp = resource.UpdateResponse{State: state, Identity: newIdentity(t, r)}
+	r.Update(ctx, resource.UpdateRequest{
+		Plan:  newPlan(t, s, organizationModel(org.ID, "team-c")),
+		State: state,
+	}, &resp)
+	requireError(t, resp.Diagnostics, "Error updating organization")
+}
+
+func TestOrganizationResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	r := &organizationResource{client: fake}
+	s := resourceSchema(t, r)
+	state := newState(t, s, organizationModel(org.ID, "team-a"))
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	if _, err := fake.GetOrganization(ctx, org.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("organization still exists: %v", err)
+	}
+
+	// A second delete reports the missing organization.
+	resp = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireError(t, resp.Diagnostics, "Error deleting organization")
+}
+
+// strictOrganizationAPI refuses to delete organizations that still have
+// projects, like the Langfuse API.
+type strictOrganizationAPI struct {
+	*clientfake.Fake
+}
+
+func (s strictOrganizationAPI) DeleteOrganization(ctx context.Context, orgID string) error {
+	projects, err := s.ListProjects(ctx, orgID)
+	if err == nil && len(projects) > 0 {
+		return errors.New("organization still has projects")
+	}
+	return s.Fake.DeleteOrganization(ctx, orgID)
+}
+
+func TestOrganizationResourceDeleteProjects(t *testing.T) {
+	for _, deleteProjects := range []bool{false, true} {
+		ctx := context.Background()
+		fake := clientfake.New()
+		org, _ := fake.CreateOrganization(ctx, "team-a")
+		fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+		fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "chat"})
+		r := &organizationResource{client: strictOrganizationAPI{fake}, deleteProjects: deleteProjects}
+		s := resourceSchema(t, r)
+		state := newState(t, s, organizationModel(org.ID, "team-a"))
+
+		resp := resource.DeleteResponse{State: state}
+		r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+		if !deleteProjects {
+			requireError(t, resp.Diagnostics, "still has projects")
+			continue
+		}
+		requireNoErrors(t, resp.Diagnostics)
+		if _, err := fake.GetOrganization(ctx, org.ID); !errors.Is(err, client.ErrNotFound) {
+			t.Errorf("organization still exists: %v", err)
+		}
+	}
+}
+
+func TestOrganizationResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	a, _ := fake.CreateOrganization(ctx, "team-a")
+	fake.CreateOrganization(ctx, "twin")
+	fake.CreateOrganization(ctx, "twin")
+	r := &organizationResource{client: fake}
+	s := resourceSchema(t, r)
+
+	tests := []struct {
+		id      string
+		wantID  string
+		wantErr string
+	}{
+		{id: a.ID, wantID: a.ID},
+		{id: "name:team-a", wantID: a.ID},
+		{id: "name:Team-A", wantErr: "Organization not found"},
+		{id: "name:twin", wantErr: "Ambiguous organization name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			resp := resource.ImportStateResponse{State: newState(t, s, nil), Identity: newIdentity(t, r)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, &resp)
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var id types.String
+			requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("id"), &id))
+			if id.ValueString() != tt.wantID {
+				t.Errorf("id = %s, want %s", id, tt.wantID)
+			}
+		})
+	}
+
+	t.Run("identity", func(t *testing.T) {
+		identity := newIdentity(t, r)
+		requireNoErrors(t, identity.Set(ctx, organizationIdentityModel{ID: types.StringValue(a.ID)}))
+		resp := resource.ImportStateResponse{State: newState(t, s, nil), Identity: identity}
+		r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, &resp)
+		requireNoErrors(t, resp.Diagnostics)
+		var id types.String
+		requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("id"), &id))
+		if id.ValueString() != a.ID {
+			t.Errorf("id = %s, want %s", id, a.ID)
+		}
+	})
+}
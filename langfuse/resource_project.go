@@ -1,18 +1,38 @@
 package langfuse
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
+	"strings"
 
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // projectResource implements the langfuse_project resource.
 type projectResource struct {
-	client *client.Client
+	client client.LangfuseAPI
+	// skipReadAfterCreate disables waiting for created objects to be readable.
+	skipReadAfterCreate bool
 }
 
 // NewProjectResource returns a new projectResource.
@@ -20,6 +40,13 @@ func NewProjectResource() resource.Resource {
 	return &projectResource{}
 }
 
+var (
+	_ resource.ResourceWithModifyPlan       = &projectResource{}
+	_ resource.ResourceWithUpgradeState     = &projectResource{}
+	_ resource.ResourceWithConfigValidators = &projectResource{}
+	_ resource.ResourceWithIdentity         = &projectResource{}
+)
+
 // Metadata sets the resource type name.
 func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "langfuse_project"
@@ -28,41 +55,130 @@ func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataReq
 // Schema defines the schema for projects.
 func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     projectSchemaVersion,
 		Description: "Resource for managing Langfuse projects (within an organization).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
 				Description: "ID of the project.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				Required:    true,
-				Description: "Name of the project.",
+				Description: "Name of the project. Must be 3 to 60 characters long.",
+				Validators:  []validator.String{validName()},
+			},
+			"ignore_name_case": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether `name` is compared case-insensitively with the name stored by Langfuse, so a name that differs only in case is not reported as a change. Surrounding whitespace is always ignored. Defaults to false.",
 			},
 			"organization_id": schema.StringAttribute{
 				Required:    true,
-				Description: "ID of the parent organization.",
+				Description: "ID of the parent organization. Changing it replaces the project, which generates a new key pair.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"metadata": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Free-form key/value metadata attached to the project, e.g. cost center or owning team.",
+			},
+			"duplicate_name_check": schema.StringAttribute{
+				Optional:    true,
+				Description: "Whether to check during plan that no other project of the organization has the same name, as Langfuse allows duplicates: `off` (the default), `warn` or `error`. The check runs when the project is created, renamed or moved.",
+				Validators:  []validator.String{stringOneOf("off", "warn", "error")},
 			},
 			"public_key": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
 				Description: "Public API key for this project (returned on create).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"secret_key": schema.StringAttribute{
 				Computed:    true,
 				Sensitive:   true,
-				Description: "Secret API key for this project (returned on create).",
+				Description: "Secret API key for this project (returned on create). Null when `store_secret_key` is false.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
+			"secret_key_fingerprint": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the secret key, in the form `sha256:<hex>`. It is recorded on create even when `store_secret_key` is false, so the key held by a secrets manager can be matched to the project without storing the key itself. Null for imported projects.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"store_secret_key": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the secret key is persisted in Terraform state. Set to false together with `secret_key_command` to hand the key to a secrets manager at create time without it ever being written to state; only `public_key` and `secret_key_fingerprint` are kept. Langfuse does not return the key again, so it cannot be recovered from state later, and switching this back to true does not bring it back. The key is generated by Langfuse, so it cannot be a write-only attribute. Defaults to true.",
+			},
+			"secret_key_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Command (program followed by its arguments) run once after the project is created, receiving the secret key on standard input and `LANGFUSE_PROJECT_ID`, `LANGFUSE_ORGANIZATION_ID` and `LANGFUSE_PUBLIC_KEY` in its environment, e.g. `[\"vault\", \"kv\", \"put\", \"secret/langfuse/app\", \"secret_key=-\"]`. If it fails, the project is deleted again so no undelivered key is left behind.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
 // projectResourceModel maps project resource schema.
 type projectResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	IgnoreCase     types.Bool     `tfsdk:"ignore_name_case"`
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Metadata       types.Map      `tfsdk:"metadata"`
+	DuplicateCheck types.String   `tfsdk:"duplicate_name_check"`
+	PublicKey      types.String   `tfsdk:"public_key"`
+	SecretKey      types.String   `tfsdk:"secret_key"`
+	SecretKeyHash  types.String   `tfsdk:"secret_key_fingerprint"`
+	StoreSecretKey types.Bool     `tfsdk:"store_secret_key"`
+	SecretKeyCmd   types.List     `tfsdk:"secret_key_command"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// projectIdentityModel maps the identity schema of projects.
+type projectIdentityModel struct {
 	OrganizationID types.String `tfsdk:"organization_id"`
-	PublicKey      types.String `tfsdk:"public_key"`
-	SecretKey      types.String `tfsdk:"secret_key"`
+	ID             types.String `tfsdk:"id"`
+}
+
+// identity returns the identity of the project m describes.
+func (m projectResourceModel) identity() projectIdentityModel {
+	return projectIdentityModel{OrganizationID: m.OrganizationID, ID: m.ID}
+}
+
+// IdentitySchema defines the identity of projects, used to import them with
+// an identity block and to match results of terraform query.
+func (r *projectResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"organization_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the parent organization.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the project.",
+			},
+		},
+	}
 }
 
 // Configure injects the Langfuse client.
@@ -70,19 +186,135 @@ func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	clientData, ok := req.ProviderData.(*client.Client)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *resourceData, got %T", req.ProviderData),
 		)
 		return
 	}
-	r.client = clientData
+	r.client = data.client
+	r.skipReadAfterCreate = !data.readAfterCreate
+}
+
+// ConfigValidators returns the cross-attribute rules of the configuration.
+func (r *projectResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// The key is generated by Langfuse and never returned again, so it
+		// has to be stored or delivered.
+		requiredWhen("store_secret_key", types.BoolValue(false), "secret_key_command").
+			asWarning("The secret key of new projects is not kept anywhere, and Langfuse does not return it again."),
+	}
+}
+
+// ModifyPlan keeps the planned key attributes in line with what apply will
+// store, and verifies during plan that the parent organization exists, so a
+// mistyped organization_id is reported before anything is created, and
+// optionally that the project name is not taken yet. The checks only run when
+// the values involved are known and the project is being created, renamed or
+// moved to another organization.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan projectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *projectResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &projectResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	moved := state == nil || !state.OrganizationID.Equal(plan.OrganizationID)
+	// A replaced project is planned again without state, where the ID and
+	// keys stay unknown; in place, they are kept from state, except for a
+	// secret key that apply is going to drop because store_secret_key is false.
+	if state != nil && !plan.StoreSecretKey.IsUnknown() && !plan.StoreSecretKey.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_key"), types.StringNull())...)
+	}
+	if r.client == nil || plan.OrganizationID.IsUnknown() || plan.OrganizationID.IsNull() {
+		return
+	}
+	if moved && !r.checkOrganization(ctx, plan.OrganizationID.ValueString(), &resp.Diagnostics) {
+		return
+	}
+	if moved || !state.Name.Equal(plan.Name) {
+		r.checkDuplicateName(ctx, plan, &resp.Diagnostics)
+	}
+}
+
+// checkOrganization reports whether organization orgID exists, adding an
+// error if it does not. Other failures only add a warning and report true.
+func (r *projectResource) checkOrganization(ctx context.Context, orgID string, diags *diag.Diagnostics) bool {
+	_, err := r.client.GetOrganization(ctx, orgID)
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		diags.AddAttributeError(
+			path.Root("organization_id"),
+			"Organization not found",
+			fmt.Sprintf("No organization with ID %q exists on the Langfuse instance.", orgID),
+		)
+		return false
+	case err != nil:
+		// Do not fail the plan on transient errors; apply reports real problems.
+		diags.AddAttributeWarning(
+			path.Root("organization_id"),
+			"Unable to verify organization",
+			fmt.Sprintf("Checking that organization %q exists failed: %s", orgID, err),
+		)
+	}
+	return true
+}
+
+// checkDuplicateName reports other projects of the planned organization
+// sharing the planned name, as configured by duplicate_name_check.
+func (r *projectResource) checkDuplicateName(ctx context.Context, plan projectResourceModel, diags *diag.Diagnostics) {
+	mode := plan.DuplicateCheck.ValueString()
+	if mode == "" || mode == "off" || plan.Name.IsUnknown() {
+		return
+	}
+	orgID := plan.OrganizationID.ValueString()
+	projects, err := r.client.ListProjects(ctx, orgID)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("name"),
+			"Unable to check project name",
+			fmt.Sprintf("Listing the projects of organization %q failed: %s", orgID, err),
+		)
+		return
+	}
+
+	var ids []string
+	for _, proj := range projects {
+		// An unknown ID (create) never matches an existing project.
+		if proj.Name == plan.Name.ValueString() && proj.ID != plan.ID.ValueString() {
+			ids = append(ids, proj.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	summary := "Duplicate project name"
+	detail := fmt.Sprintf("Organization %q already has a project named %q (IDs %s). Langfuse accepts this, but the projects are hard to tell apart in the UI.", orgID, plan.Name.ValueString(), strings.Join(ids, ", "))
+	if mode == "error" {
+		diags.AddAttributeError(path.Root("name"), summary, detail)
+		return
+	}
+	diags.AddAttributeWarning(path.Root("name"), summary, detail)
 }
 
 // Create calls the API to create a new project.
 func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var plan projectResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -90,23 +322,76 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	proj, err := r.client.CreateProject(ctx, plan.OrganizationID.ValueString(), plan.Name.ValueString())
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	metadata, diags := projectMetadata(ctx, plan.Metadata)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	proj, err := r.client.CreateProject(ctx, plan.OrganizationID.ValueString(), client.ProjectCreate{
+		Name:     plan.Name.ValueString(),
+		Metadata: metadata,
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating project", err.Error())
+		addClientError(&resp.Diagnostics, "Error creating project", err)
 		return
 	}
 
 	plan.ID = types.StringValue(proj.ID)
-	plan.Name = types.StringValue(proj.Name)
+	plan.Name = remoteName(plan.Name, proj.Name, plan.IgnoreCase)
 	plan.OrganizationID = types.StringValue(proj.OrganizationID)
 	plan.PublicKey = types.StringValue(proj.PublicKey)
 	plan.SecretKey = types.StringValue(proj.SecretKey)
+	plan.SecretKeyHash = types.StringValue(secretKeyFingerprint(proj.SecretKey))
+
+	if !plan.SecretKeyCmd.IsNull() {
+		var args []string
+		resp.Diagnostics.Append(plan.SecretKeyCmd.ElementsAs(ctx, &args, false)...)
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.Append(deliverSecretKey(ctx, args, proj)...)
+		}
+		if resp.Diagnostics.HasError() {
+			// Without state the key would be lost; remove the project so the
+			// next apply starts over.
+			if err := r.client.DeleteProject(ctx, proj.OrganizationID, proj.ID); err != nil {
+				addClientError(&resp.Diagnostics, fmt.Sprintf("Error deleting project %s after failed key delivery", proj.ID), err)
+			}
+			return
+		}
+	}
+	if !plan.StoreSecretKey.ValueBool() {
+		plan.SecretKey = types.StringNull()
+	}
+
+	if !r.skipReadAfterCreate {
+		err = waitForVisibility(ctx, "project "+proj.ID, func(ctx context.Context) error {
+			_, err := r.client.GetProject(ctx, proj.OrganizationID, proj.ID)
+			return err
+		})
+		if err != nil {
+			resp.Diagnostics.AddWarning(
+				"Project not yet readable",
+				fmt.Sprintf("Project %s was created but could not be read back yet: %s\n\nThe next refresh may report it as missing if it does not become visible.", proj.ID, err),
+			)
+		}
+	}
 
 	resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 }
 
 // Read refreshes the project state from the API.
 func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var state projectResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -114,39 +399,117 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	proj, err := r.client.GetProject(ctx, state.OrganizationID.ValueString(), state.ID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// Deleted outside of Terraform: drop it so the next apply recreates it.
+		tflog.Warn(ctx, "Project not found, removing it from state", map[string]interface{}{
+			"organization_id": state.OrganizationID.ValueString(),
+			"id":              state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading project", err.Error())
+		addClientError(&resp.Diagnostics, "Error reading project", err)
 		return
 	}
 
-	state.Name = types.StringValue(proj.Name)
+	state.Name = remoteName(state.Name, proj.Name, state.IgnoreCase)
 	state.PublicKey = types.StringValue(proj.PublicKey)
+	if state.StoreSecretKey.IsNull() {
+		// Imported projects: apply the schema default.
+		state.StoreSecretKey = types.BoolValue(true)
+	}
+	// An unset attribute and empty metadata are equivalent; keep what is in state.
+	if len(proj.Metadata) > 0 || len(state.Metadata.Elements()) > 0 {
+		state.Metadata, diags = projectMetadataValue(proj.Metadata)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 	// Note: SecretKey is not returned by GET; keep the previous state value intact.
+	if state.SecretKeyHash.IsNull() && state.SecretKey.ValueString() != "" {
+		// State written before fingerprints were recorded.
+		state.SecretKeyHash = types.StringValue(secretKeyFingerprint(state.SecretKey.ValueString()))
+	}
 
 	resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, state.identity())...)
 }
 
-// Update renames the project via the API.
+// Update sends the changed project attributes to the API.
 func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan projectResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
+	defer logClientMetrics(ctx, r.client)
+
+	var plan, state projectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	_, err := r.client.UpdateProject(ctx, plan.OrganizationID.ValueString(), plan.ID.ValueString(), plan.Name.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error updating project", err.Error())
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Only send attributes that changed, so fields not managed here are kept.
+	var update client.ProjectUpdate
+	if !plan.Name.Equal(state.Name) {
+		update.Name = plan.Name.ValueStringPointer()
+	}
+	if !plan.Metadata.Equal(state.Metadata) {
+		metadata, diags := projectMetadata(ctx, plan.Metadata)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+		update.Metadata = &metadata
+	}
+	if !update.IsEmpty() {
+		_, err := r.client.UpdateProject(ctx, plan.OrganizationID.ValueString(), plan.ID.ValueString(), update)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "Error updating project", err)
+			return
+		}
+	}
+	// The key pair does not change on update.
+	plan.PublicKey = state.PublicKey
+	plan.SecretKey = state.SecretKey
+	plan.SecretKeyHash = state.SecretKeyHash
+	if !plan.StoreSecretKey.ValueBool() {
+		plan.SecretKey = types.StringNull()
+	} else if !state.StoreSecretKey.ValueBool() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("store_secret_key"),
+			"Secret key not available",
+			"Langfuse only returns the secret key when a project is created. It was not stored before, so `secret_key` stays null; recreate the project or use the key delivered by `secret_key_command`.",
+		)
+	}
 
 	resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, plan.identity())...)
 }
 
 // Delete removes the project via the API.
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer logClientMetrics(ctx, r.client)
+
 	var state projectResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -154,31 +517,192 @@ func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	if err := r.client.DeleteProject(ctx, state.OrganizationID.ValueString(), state.ID.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting project", err.Error())
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	orgID, projID := state.OrganizationID.ValueString(), state.ID.ValueString()
+	if err := r.client.DeleteProject(ctx, orgID, projID); err != nil {
+		addClientError(&resp.Diagnostics, "Error deleting project", err)
+		return
+	}
+
+	// Langfuse removes project data in the background. Wait until the project
+	// is gone so that recreating one with the same name does not collide.
+	err := waitForDeletion(ctx, "project "+projID, func(ctx context.Context) error {
+		_, err := r.client.GetProject(ctx, orgID, projID)
+		return err
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "Error waiting for project deletion", err)
 	}
 }
 
-// ImportState allows importing an existing project by “orgID/projectID” composite ID.
+// ImportState allows importing an existing project by “orgID/projectID”
+// composite ID, or by project ID alone, in which case the owning organization
+// is looked up through the API, as well as by identity.
 func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Expect the ID in the form "orgID/projectID"
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid import identifier",
-			"Expected import ID in the form \"<organization_id>/<project_id>\" (e.g. \"org123/proj456\").",
-		)
+	if req.ID == "" {
+		var identity projectIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), identity.OrganizationID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
 		return
 	}
 
-	orgID := parts[0]
-	projID := parts[1]
+	orgID, projID, err := parseProjectImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import identifier", err.Error())
+		return
+	}
+	if orgID == "" {
+		var diags diag.Diagnostics
+		orgID, diags = r.findProjectOrganization(ctx, projID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Set both organization_id and id in the Terraform state
-	resp.Diagnostics.Append(
-		resp.State.SetAttribute(ctx, path.Root("organization_id"), types.StringValue(orgID)),
-		resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(projID)),
-	)... 
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), types.StringValue(orgID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(projID))...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, projectIdentityModel{
+		OrganizationID: types.StringValue(orgID),
+		ID:             types.StringValue(projID),
+	})...)
 
 	// After setting those two, Terraform will call Read() automatically to populate the rest.
 }
+
+// parseProjectImportID splits a project import ID into its organization and
+// project IDs. orgID is empty when id only names the project.
+func parseProjectImportID(id string) (orgID, projID string, err error) {
+	switch parts := strings.Split(id, "/"); len(parts) {
+	case 1:
+		projID = parts[0]
+	case 2:
+		orgID, projID = parts[0], parts[1]
+		if err := checkImportIDPart("organization_id", orgID); err != nil {
+			return "", "", fmt.Errorf("%s in %q.", err, id)
+		}
+	default:
+		return "", "", errors.New("Expected import ID in the form \"<organization_id>/<project_id>\" (e.g. \"org123/proj456\") or \"<project_id>\".")
+	}
+	if err := checkImportIDPart("project_id", projID); err != nil {
+		return "", "", fmt.Errorf("%s in %q.", err, id)
+	}
+	return orgID, projID, nil
+}
+
+// findProjectOrganization returns the ID of the organization owning projID.
+// The admin API has no lookup by project ID, so every organization is asked.
+func (r *projectResource) findProjectOrganization(ctx context.Context, projID string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	orgs, err := r.client.ListOrganizations(ctx)
+	if err != nil {
+		addClientError(&diags, "Error listing organizations", err)
+		return "", diags
+	}
+	for _, org := range orgs {
+		_, err := r.client.GetProject(ctx, org.ID, projID)
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			addClientError(&diags, fmt.Sprintf("Error looking up project %s in organization %s", projID, org.ID), err)
+			return "", diags
+		}
+		return org.ID, diags
+	}
+	diags.AddError(
+		"Project not found",
+		fmt.Sprintf("None of the %d organizations contains a project with ID %q.", len(orgs), projID),
+	)
+	return "", diags
+}
+
+// secretKeyFingerprint returns the fingerprint of a project secret key as
+// stored in secret_key_fingerprint.
+func secretKeyFingerprint(secretKey string) string {
+	sum := sha256.Sum256([]byte(secretKey))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// projectMetadata converts the metadata attribute to its API representation.
+// A null attribute yields nil.
+func projectMetadata(ctx context.Context, value types.Map) (map[string]any, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+	var elems map[string]string
+	diags := value.ElementsAs(ctx, &elems, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	metadata := make(map[string]any, len(elems))
+	for k, v := range elems {
+		metadata[k] = v
+	}
+	return metadata, diags
+}
+
+// projectMetadataValue converts metadata returned by the API to the attribute
+// value. Values that are not strings, e.g. set by other clients, are kept as
+// their JSON encoding.
+func projectMetadataValue(metadata map[string]any) (types.Map, diag.Diagnostics) {
+	elems := make(map[string]attr.Value, len(metadata))
+	for k, v := range metadata {
+		if s, ok := v.(string); ok {
+			elems[k] = types.StringValue(s)
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Invalid project metadata", fmt.Sprintf("Metadata key %q has a value that cannot be represented: %s", k, err))
+			return types.MapNull(types.StringType), diags
+		}
+		elems[k] = types.StringValue(string(b))
+	}
+	return types.MapValue(types.StringType, elems)
+}
+
+// deliverSecretKey runs the secret key command for a newly created project,
+// passing the key on standard input.
+func deliverSecretKey(ctx context.Context, args []string, proj *client.Project) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if len(args) == 0 || args[0] == "" {
+		diags.AddAttributeError(
+			path.Root("secret_key_command"),
+			"Invalid secret key command",
+			"`secret_key_command` must contain at least the program to execute.",
+		)
+		return diags
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(proj.SecretKey)
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(),
+		"LANGFUSE_PROJECT_ID="+proj.ID,
+		"LANGFUSE_ORGANIZATION_ID="+proj.OrganizationID,
+		"LANGFUSE_PUBLIC_KEY="+proj.PublicKey,
+	)
+	if err := cmd.Run(); err != nil {
+		diags.AddAttributeError(
+			path.Root("secret_key_command"),
+			"Secret key command failed",
+			fmt.Sprintf("Running `secret_key_command` (%s) failed: %s\n\n%s", args[0], err, strings.TrimSpace(stderr.String())),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,477 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/faxe1008/terraform-provider-langfuse/client/clientfake"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// projectModel returns a project resource model with the defaults of a
+// minimal configuration. An empty id yields the unknown values of a plan for
+// a new project.
+func projectModel(orgID, id, name string) projectResourceModel {
+	m := projectResourceModel{
+		ID:             types.StringValue(id),
+		Name:           types.StringValue(name),
+		IgnoreCase:     types.BoolNull(),
+		OrganizationID: types.StringValue(orgID),
+		Metadata:       types.MapNull(types.StringType),
+		DuplicateCheck: types.StringNull(),
+		PublicKey:      types.StringUnknown(),
+		SecretKey:      types.StringUnknown(),
+		SecretKeyHash:  types.StringUnknown(),
+		StoreSecretKey: types.BoolValue(true),
+		SecretKeyCmd:   types.ListNull(types.StringType),
+		Timeouts:       nullTimeouts(),
+	}
+	if id == "" {
+		m.ID = types.StringUnknown()
+	}
+	return m
+}
+
+// createdProjectModel returns the state model of an existing project.
+func createdProjectModel(proj *client.Project) projectResourceModel {
+	m := projectModel(proj.OrganizationID, proj.ID, proj.Name)
+	m.PublicKey = types.StringValue(proj.PublicKey)
+	m.SecretKey = types.StringValue(proj.SecretKey)
+	m.SecretKeyHash = types.StringValue(secretKeyFingerprint(proj.SecretKey))
+	return m
+}
+
+// metadataValue returns a metadata attribute value holding m.
+func metadataValue(t *testing.T, m map[string]string) types.Map {
+	t.Helper()
+	v, diags := types.MapValueFrom(context.Background(), types.StringType, m)
+	requireNoErrors(t, diags)
+	return v
+}
+
+func TestProjectResourceCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		orgID        string
+		store        bool
+		metadata     map[string]string
+		err          error
+		wantErr      string
+		wantNoSecret bool
+	}{
+		{name: "success", store: true},
+		{name: "with metadata", store: true, metadata: map[string]string{"team": "search"}},
+		{name: "secret key not stored", store: false, wantNoSecret: true},
+		{name: "missing organization", orgID: "org-missing", store: true, wantErr: "Error creating project"},
+		{name: "api error", store: true, err: errors.New("connection reset"), wantErr: "Error creating project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			fake.Err = tt.err
+			r := &projectResource{client: fake}
+			s := resourceSchema(t, r)
+
+			orgID := org.ID
+			if tt.orgID != "" {
+				orgID = tt.orgID
+			}
+			plan := projectModel(orgID, "", "search")
+			plan.StoreSecretKey = types.BoolValue(tt.store)
+			if tt.metadata != nil {
+				plan.Metadata = metadataValue(t, tt.metadata)
+			}
+			resp := resource.CreateResponse{State: newState(t, s, nil), Identity: newIdentity(t, r)}
+			r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, plan)}, &resp)
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				if !resp.State.Raw.IsNull() {
+					t.Error("state was set despite the error")
+				}
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got projectResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			proj, err := fake.GetProject(ctx, org.ID, got.ID.ValueString())
+			if err != nil {
+				t.Fatalf("project %s not created: %v", got.ID, err)
+			}
+			if got.PublicKey.ValueString() != proj.PublicKey {
+				t.Errorf("public_key = %s, want %s", got.PublicKey, proj.PublicKey)
+			}
+			if got.SecretKey.IsNull() != tt.wantNoSecret {
+				t.Errorf("secret_key null = %t, want %t", got.SecretKey.IsNull(), tt.wantNoSecret)
+			}
+			if got.SecretKeyHash.IsNull() || got.SecretKeyHash.IsUnknown() {
+				t.Error("secret_key_fingerprint not set")
+			}
+			if len(proj.Metadata) != len(tt.metadata) {
+				t.Errorf("API metadata = %v, want %v", proj.Metadata, tt.metadata)
+			}
+		})
+	}
+}
+
+// countingProjectAPI counts project reads.
+type countingProjectAPI struct {
+	*clientfake.Fake
+	gets int
+}
+
+func (c *countingProjectAPI) GetProject(ctx context.Context, orgID, projID string) (*client.Project, error) {
+	c.gets++
+	return c.Fake.GetProject(ctx, orgID, projID)
+}
+
+func TestProjectResourceCreateReadBack(t *testing.T) {
+	for _, skip := range []bool{false, true} {
+		ctx := context.Background()
+		api := &countingProjectAPI{Fake: clientfake.New()}
+		org, _ := api.CreateOrganization(ctx, "team-a")
+		r := &projectResource{client: api, skipReadAfterCreate: skip}
+		s := resourceSchema(t, r)
+
+		resp := resource.CreateResponse{State: newState(t, s, nil), Identity: newIdentity(t, r)}
+		r.Create(ctx, resource.CreateRequest{Plan: newPlan(t, s, projectModel(org.ID, "", "search"))}, &resp)
+		requireNoErrors(t, resp.Diagnostics)
+		want := 1
+		if skip {
+			want = 0
+		}
+		if api.gets != want {
+			t.Errorf("skipReadAfterCreate = %t: project read %d times after create, want %d", skip, api.gets, want)
+		}
+	}
+}
+
+// partialProjectAPI returns projects without metadata, like instances that
+// predate project metadata.
+type partialProjectAPI struct {
+	*clientfake.Fake
+}
+
+func (p partialProjectAPI) GetProject(ctx context.Context, orgID, projID string) (*client.Project, error) {
+	proj, err := p.Fake.GetProject(ctx, orgID, projID)
+	if proj != nil {
+		proj.Metadata = nil
+	}
+	return proj, err
+}
+
+func TestProjectResourceRead(t *testing.T) {
+	tests := []struct {
+		name         string
+		partial      bool
+		delete       bool
+		err          error
+		stateMeta    map[string]string
+		wantMeta     int
+		wantRemoved  bool
+		wantErr      string
+		wantImported bool
+	}{
+		{name: "unchanged", stateMeta: map[string]string{"team": "search"}, wantMeta: 1},
+		{name: "metadata missing from response", partial: true, stateMeta: map[string]string{"team": "search"}},
+		{name: "imported", wantImported: true, wantMeta: 1},
+		{name: "deleted outside of terraform", delete: true, wantRemoved: true},
+		{name: "api error", err: errors.New("internal server error"), wantErr: "Error reading project"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := clientfake.New()
+			org, _ := fake.CreateOrganization(ctx, "team-a")
+			proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{
+				Name:     "search",
+				Metadata: map[string]any{"team": "search"},
+			})
+			if tt.delete {
+				fake.DeleteProject(ctx, org.ID, proj.ID)
+			}
+			fake.Err = tt.err
+			var api client.LangfuseAPI = fake
+			if tt.partial {
+				api = partialProjectAPI{fake}
+			}
+			r := &projectResource{client: api}
+			s := resourceSchema(t, r)
+
+			prior := createdProjectModel(proj)
+			if tt.stateMeta != nil {
+				prior.Metadata = metadataValue(t, tt.stateMeta)
+			}
+			if tt.wantImported {
+				prior = projectModel(org.ID, proj.ID, "")
+				prior.Name = types.StringNull()
+				prior.PublicKey = types.StringNull()
+				prior.SecretKey = types.StringNull()
+				prior.SecretKeyHash = types.StringNull()
+				prior.StoreSecretKey = types.BoolNull()
+			}
+			state := newState(t, s, prior)
+			resp := resource.ReadResponse{State: state, Identity: newIdentity(t, r)}
+			r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+			switch {
+			case tt.wantErr != "":
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			case tt.wantRemoved:
+				requireNoErrors(t, resp.Diagnostics)
+				if !resp.State.Raw.IsNull() {
+					t.Error("deleted project was not removed from state")
+				}
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var got projectResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+			if got.Name.ValueString() != "search" || got.PublicKey.ValueString() != proj.PublicKey {
+				t.Errorf("name, public_key = %s, %s; want search, %s", got.Name, got.PublicKey, proj.PublicKey)
+			}
+			if n := len(got.Metadata.Elements()); n != tt.wantMeta {
+				t.Errorf("metadata has %d elements, want %d", n, tt.wantMeta)
+			}
+			if tt.wantImported {
+				// The API never returns the secret key again.
+				if !got.SecretKey.IsNull() || !got.StoreSecretKey.ValueBool() {
+					t.Errorf("secret_key, store_secret_key = %s, %s; want null, true", got.SecretKey, got.StoreSecretKey)
+				}
+				return
+			}
+			if got.SecretKey.ValueString() != proj.SecretKey {
+				t.Errorf("secret_key was not kept from state")
+			}
+		})
+	}
+}
+
+func TestProjectResourceUpdate(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{
+		Name:     "search",
+		Metadata: map[string]any{"team": "search"},
+	})
+	r := &projectResource{client: fake}
+	s := resourceSchema(t, r)
+
+	prior := createdProjectModel(proj)
+	prior.Metadata = metadataValue(t, map[string]string{"team": "search"})
+	state := newState(t, s, prior)
+
+	// Rename, drop the metadata and stop storing the secret key.
+	plan := projectModel(org.ID, proj.ID, "ranking")
+	plan.StoreSecretKey = types.BoolValue(false)
+	resp := resource.UpdateResponse{State: state, Identity: newIdentity(t, r)}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, plan), State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+
+	got, _ := fake.GetProject(ctx, org.ID, proj.ID)
+	if got.Name != "ranking" || len(got.Metadata) != 0 {
+		t.Errorf("API name, metadata = %s, %v; want ranking, none", got.Name, got.Metadata)
+	}
+	var updated projectResourceModel
+	requireNoErrors(t, resp.State.Get(ctx, &updated))
+	if updated.PublicKey.ValueString() != proj.PublicKey || !updated.SecretKey.IsNull() {
+		t.Errorf("public_key, secret_key = %s, %s; want %s, null", updated.PublicKey, updated.SecretKey, proj.PublicKey)
+	}
+	if !updated.SecretKeyHash.Equal(prior.SecretKeyHash) {
+		t.Errorf("secret_key_fingerprint = %s, want %s", updated.SecretKeyHash, prior.SecretKeyHash)
+	}
+
+	fake.Err = errors.New("bad gateway")
+	resp = resource.UpdateResponse{State: state, Identity: newIdentity(t, r)}
+	r.Update(ctx, resource.UpdateRequest{Plan: newPlan(t, s, projectModel(org.ID, proj.ID, "other")), State: state}, &resp)
+	requireError(t, resp.Diagnostics, "Error updating project")
+}
+
+func TestProjectResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	r := &projectResource{client: fake}
+	s := resourceSchema(t, r)
+	state := newState(t, s, createdProjectModel(proj))
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	if _, err := fake.GetProject(ctx, org.ID, proj.ID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("project still exists: %v", err)
+	}
+
+	resp = resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	requireError(t, resp.Diagnostics, "Error deleting project")
+}
+
+func TestProjectResourceImportState(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	fake.CreateOrganization(ctx, "team-a")
+	org, _ := fake.CreateOrganization(ctx, "team-b")
+	proj, _ := fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	r := &projectResource{client: fake}
+	s := resourceSchema(t, r)
+
+	tests := []struct {
+		id        string
+		wantOrgID string
+		wantErr   string
+	}{
+		{id: org.ID + "/" + proj.ID, wantOrgID: org.ID},
+		{id: proj.ID, wantOrgID: org.ID},
+		{id: "proj-missing", wantErr: "Project not found"},
+		{id: "a/b/c", wantErr: "Invalid import identifier"},
+		{id: "/" + proj.ID, wantErr: "Invalid import identifier"},
+		{id: org.ID + "/", wantErr: "Invalid import identifier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			resp := resource.ImportStateResponse{State: newState(t, s, nil), Identity: newIdentity(t, r)}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: tt.id}, &resp)
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			var orgID, id types.String
+			requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("organization_id"), &orgID))
+			requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("id"), &id))
+			if orgID.ValueString() != tt.wantOrgID || id.ValueString() != proj.ID {
+				t.Errorf("organization_id, id = %s, %s; want %s, %s", orgID, id, tt.wantOrgID, proj.ID)
+			}
+		})
+	}
+
+	t.Run("identity", func(t *testing.T) {
+		identity := newIdentity(t, r)
+		requireNoErrors(t, identity.Set(ctx, projectIdentityModel{OrganizationID: types.StringValue(org.ID), ID: types.StringValue(proj.ID)}))
+		resp := resource.ImportStateResponse{State: newState(t, s, nil), Identity: identity}
+		r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, &resp)
+		requireNoErrors(t, resp.Diagnostics)
+		var orgID, id types.String
+		requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("organization_id"), &orgID))
+		requireNoErrors(t, resp.State.GetAttribute(ctx, path.Root("id"), &id))
+		if orgID.ValueString() != org.ID || id.ValueString() != proj.ID {
+			t.Errorf("organization_id, id = %s, %s; want %s, %s", orgID, id, org.ID, proj.ID)
+		}
+	})
+}
+
+func TestProjectResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	org, _ := fake.CreateOrganization(ctx, "team-a")
+	fake.CreateProject(ctx, org.ID, client.ProjectCreate{Name: "search"})
+	r := &projectResource{client: fake}
+	s := resourceSchema(t, r)
+
+	tests := []struct {
+		name        string
+		orgID       string
+		check       string
+		wantErr     string
+		wantWarning bool
+	}{
+		{name: "new project", orgID: org.ID},
+		{name: "missing organization", orgID: "org-missing", wantErr: "Organization not found"},
+		{name: "duplicate name unchecked", orgID: org.ID, check: "off"},
+		{name: "duplicate name warning", orgID: org.ID, check: "warn", wantWarning: true},
+		{name: "duplicate name error", orgID: org.ID, check: "error", wantErr: "Duplicate project name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := projectModel(tt.orgID, "", "search")
+			if tt.check != "" {
+				model.DuplicateCheck = types.StringValue(tt.check)
+			}
+			plan := newPlan(t, s, model)
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: newState(t, s, nil)}, &resp)
+
+			if tt.wantErr != "" {
+				requireError(t, resp.Diagnostics, tt.wantErr)
+				return
+			}
+			requireNoErrors(t, resp.Diagnostics)
+			if got := resp.Diagnostics.WarningsCount() > 0; got != tt.wantWarning {
+				t.Errorf("warning = %t, want %t: %v", got, tt.wantWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestProjectResourceModifyPlanKeys(t *testing.T) {
+	ctx := context.Background()
+	fake := clientfake.New()
+	orgA, _ := fake.CreateOrganization(ctx, "team-a")
+	orgB, _ := fake.CreateOrganization(ctx, "team-b")
+	proj, _ := fake.CreateProject(ctx, orgA.ID, client.ProjectCreate{Name: "search"})
+	r := &projectResource{client: fake}
+	s := resourceSchema(t, r)
+	prior := createdProjectModel(proj)
+
+	// Plans are built as the framework hands them to ModifyPlan: keys are
+	// copied from state by UseStateForUnknown, and a replaced project is
+	// planned a second time without state.
+	tests := []struct {
+		name          string
+		state         *projectResourceModel
+		plan          func(m *projectResourceModel)
+		wantUnknown   bool
+		wantSecretKey types.String
+	}{
+		{name: "in-place update", state: &prior, plan: func(m *projectResourceModel) {
+			m.Name = types.StringValue("search-v2")
+		}, wantSecretKey: prior.SecretKey},
+		{name: "replacement", state: &prior, plan: func(m *projectResourceModel) {
+			m.OrganizationID = types.StringValue(orgB.ID)
+		}, wantSecretKey: prior.SecretKey},
+		{name: "replacement without state", plan: func(m *projectResourceModel) {
+			*m = projectModel(orgB.ID, "", proj.Name)
+		}, wantUnknown: true, wantSecretKey: types.StringUnknown()},
+		{name: "store_secret_key false", state: &prior, plan: func(m *projectResourceModel) {
+			m.StoreSecretKey = types.BoolValue(false)
+		}, wantSecretKey: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := prior
+			tt.plan(&model)
+			plan := newPlan(t, s, model)
+			var state any
+			if tt.state != nil {
+				state = *tt.state
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: newState(t, s, state)}, &resp)
+			requireNoErrors(t, resp.Diagnostics)
+
+			var got projectResourceModel
+			requireNoErrors(t, resp.Plan.Get(ctx, &got))
+			for name, v := range map[string]types.String{"id": got.ID, "public_key": got.PublicKey, "secret_key_fingerprint": got.SecretKeyHash} {
+				if v.IsUnknown() != tt.wantUnknown {
+					t.Errorf("planned %s = %s, want unknown %t", name, v, tt.wantUnknown)
+				}
+			}
+			if !tt.wantUnknown && (!got.ID.Equal(prior.ID) || !got.PublicKey.Equal(prior.PublicKey) || !got.SecretKeyHash.Equal(prior.SecretKeyHash)) {
+				t.Errorf("planned id, public_key, secret_key_fingerprint = %s, %s, %s; want the values from state", got.ID, got.PublicKey, got.SecretKeyHash)
+			}
+			if !got.SecretKey.Equal(tt.wantSecretKey) {
+				t.Errorf("planned secret_key = %s, want %s", got.SecretKey, tt.wantSecretKey)
+			}
+		})
+	}
+}
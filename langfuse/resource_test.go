@@ -0,0 +1,178 @@
+package langfuse
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceSchema returns the schema of r.
+func resourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+	var resp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	return resp.Schema
+}
+
+// newState returns a state of schema s holding model, or a null state if
+// model is nil.
+func newState(t *testing.T, s schema.Schema, model any) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	if model != nil {
+		requireNoErrors(t, state.Set(ctx, model))
+	}
+	return state
+}
+
+// newPlan returns a plan of schema s holding model.
+func newPlan(t *testing.T, s schema.Schema, model any) tfsdk.Plan {
+	t.Helper()
+	state := newState(t, s, model)
+	return tfsdk.Plan{Schema: s, Raw: state.Raw}
+}
+
+// newIdentity returns the null identity the framework passes to resources
+// with an identity schema before they set it.
+func newIdentity(t *testing.T, r resource.ResourceWithIdentity) *tfsdk.ResourceIdentity {
+	t.Helper()
+	ctx := context.Background()
+	var resp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &resp)
+	requireNoErrors(t, resp.Diagnostics)
+	return &tfsdk.ResourceIdentity{
+		Schema: resp.IdentitySchema,
+		Raw:    tftypes.NewValue(resp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// readDataSource reads data source d with config, a model of its schema, and
+// returns the response.
+func readDataSource(t *testing.T, d datasource.DataSource, config any) datasource.ReadResponse {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	requireNoErrors(t, schemaResp.Diagnostics)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	requireNoErrors(t, state.Set(ctx, config))
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: s, Raw: state.Raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}, &resp)
+	return resp
+}
+
+// listResources lists r with config, a model of its list schema, and
+// collects the streamed results.
+func listResources(t *testing.T, r interface {
+	list.ListResource
+	resource.ResourceWithIdentity
+}, config any, limit int64, includeResource bool) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp list.ListResourceSchemaResponse
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &schemaResp)
+	requireNoErrors(t, schemaResp.Diagnostics)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	requireNoErrors(t, state.Set(ctx, config))
+	req := list.ListRequest{
+		Config:                 tfsdk.Config{Schema: s, Raw: state.Raw},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchema(t, r),
+		ResourceIdentitySchema: newIdentity(t, r).Schema,
+	}
+	var stream list.ListResultsStream
+	r.List(ctx, req, &stream)
+	var results []list.ListResult
+	for result := range stream.Results {
+		results = append(results, result)
+	}
+	return results
+}
+
+// actionConfig returns config, a model of the schema of a, as configuration.
+func actionConfig(t *testing.T, a action.Action, config any) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	requireNoErrors(t, schemaResp.Diagnostics)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	requireNoErrors(t, state.Set(ctx, config))
+	return tfsdk.Config{Schema: s, Raw: state.Raw}
+}
+
+// invokeAction invokes a with config and returns the response along with the
+// progress messages sent.
+func invokeAction(t *testing.T, a action.Action, config any) (action.InvokeResponse, []string) {
+	t.Helper()
+	var progress []string
+	resp := action.InvokeResponse{SendProgress: func(event action.InvokeProgressEvent) {
+		progress = append(progress, event.Message)
+	}}
+	a.Invoke(context.Background(), action.InvokeRequest{Config: actionConfig(t, a, config)}, &resp)
+	return resp, progress
+}
+
+// runFunction calls f with args and returns its result, or its error.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+	var def function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &def)
+	result, funcErr := def.Definition.Return.NewResultData(ctx)
+	if funcErr != nil {
+		t.Fatal(funcErr)
+	}
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+// requireFuncError fails the test unless err is set and mentions want.
+func requireFuncError(t *testing.T, err *function.FuncError, want string) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("got error %v, want one containing %q", err, want)
+	}
+}
+
+// requireNoErrors fails the test if diags contains errors.
+func requireNoErrors(t *testing.T, diags diag.Diagnostics) {
+	t.Helper()
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags.Errors())
+	}
+}
+
+// requireError fails the test unless diags contains an error mentioning want
+// in its summary or detail. API errors are summarized by the failed request,
+// with the summary passed by the resource moved to the detail.
+func requireError(t *testing.T, diags diag.Diagnostics, want string) {
+	t.Helper()
+	for _, d := range diags.Errors() {
+		if strings.Contains(d.Summary()+"\n"+d.Detail(), want) {
+			return
+		}
+	}
+	t.Fatalf("expected error mentioning %q, got %v", want, diags)
+}
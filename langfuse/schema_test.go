@@ -0,0 +1,108 @@
+package langfuse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// updateSchemas rewrites the golden files instead of comparing against them:
+// go test ./langfuse -run TestSchemaSnapshots -update-schemas
+var updateSchemas = flag.Bool("update-schemas", false, "rewrite the schema snapshots in testdata/schemas")
+
+// TestSchemaSnapshots compares the schemas served by the provider with the
+// snapshots in testdata/schemas, so that schema changes, and breaking ones in
+// particular, are always part of a reviewed diff.
+func TestSchemaSnapshots(t *testing.T) {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(NewProvider("test"))()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identities, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range append(resp.Diagnostics, identities.Diagnostics...) {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	snapshots := map[string]any{"provider": resp.Provider}
+	for name, s := range resp.ResourceSchemas {
+		snapshots[filepath.Join("resources", name)] = s
+	}
+	for name, s := range resp.DataSourceSchemas {
+		snapshots[filepath.Join("data_sources", name)] = s
+	}
+	for name, s := range resp.EphemeralResourceSchemas {
+		snapshots[filepath.Join("ephemeral_resources", name)] = s
+	}
+	for name, s := range resp.ListResourceSchemas {
+		snapshots[filepath.Join("list_resources", name)] = s
+	}
+	for name, s := range resp.ActionSchemas {
+		snapshots[filepath.Join("actions", name)] = s
+	}
+	for name, s := range identities.IdentitySchemas {
+		snapshots[filepath.Join("resource_identities", name)] = s
+	}
+	for name, fn := range resp.Functions {
+		snapshots[filepath.Join("functions", name)] = fn
+	}
+
+	for name, v := range snapshots {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			enc.SetIndent("", "  ")
+			if err := enc.Encode(v); err != nil {
+				t.Fatal(err)
+			}
+			got := buf.Bytes()
+			file := filepath.Join("testdata", "schemas", name+".json")
+			if *updateSchemas {
+				if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(file, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatalf("no snapshot for %s; run the test with -update-schemas to create it: %v", name, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("schema of %s differs from %s; if the change is intended, run the test with -update-schemas and review the diff.\n\ngot:\n%s", name, file, got)
+			}
+		})
+	}
+
+	// Snapshots of removed schemas must be deleted as well.
+	files, _ := filepath.Glob(filepath.Join("testdata", "schemas", "*", "*.json"))
+	for _, file := range files {
+		rel, _ := filepath.Rel(filepath.Join("testdata", "schemas"), file)
+		if _, ok := snapshots[rel[:len(rel)-len(".json")]]; !ok {
+			if *updateSchemas {
+				os.Remove(file)
+				continue
+			}
+			t.Errorf("snapshot %s has no matching schema", file)
+		}
+	}
+}
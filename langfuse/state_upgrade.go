@@ -0,0 +1,131 @@
+package langfuse
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Schema versions of the resources. Bump the version and add a
+// StateUpgrader whenever a schema change needs existing state to be rewritten.
+const (
+	organizationSchemaVersion = 1
+	projectSchemaVersion      = 1
+)
+
+// nullTimeouts returns an unset timeouts block for the given operations.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{Object: types.ObjectNull(map[string]attr.Type{
+		"create": types.StringType,
+		"read":   types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	})}
+}
+
+// decodeRawState unmarshals the JSON state of req into v. Version 0 states are
+// decoded from raw JSON rather than a prior schema, because attributes were
+// added during version 0 and its states do not share one shape.
+func decodeRawState(req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, v any) bool {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "The prior state has no JSON representation.")
+		return false
+	}
+	if err := json.Unmarshal(req.RawState.JSON, v); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "Decoding the prior state failed: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState.
+func (r *organizationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeOrganizationStateV0},
+	}
+}
+
+// upgradeOrganizationStateV0 adds the timeouts block to version 0 state.
+func upgradeOrganizationStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior struct {
+		ID   *string `json:"id"`
+		Name *string `json:"name"`
+	}
+	if !decodeRawState(req, resp, &prior) {
+		return
+	}
+	state := organizationResourceModel{
+		ID:         types.StringPointerValue(prior.ID),
+		Name:       types.StringPointerValue(prior.Name),
+		IgnoreCase: types.BoolNull(),
+		Timeouts:   nullTimeouts(),
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// UpgradeState implements resource.ResourceWithUpgradeState.
+func (r *projectResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeProjectStateV0},
+	}
+}
+
+// upgradeProjectStateV0 carries version 0 state over, filling in attributes
+// the state predates: no metadata, a stored secret key, its fingerprint and no
+// timeouts.
+func upgradeProjectStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior struct {
+		ID               *string           `json:"id"`
+		Name             *string           `json:"name"`
+		OrganizationID   *string           `json:"organization_id"`
+		Metadata         map[string]string `json:"metadata"`
+		PublicKey        *string           `json:"public_key"`
+		SecretKey        *string           `json:"secret_key"`
+		StoreSecretKey   *bool             `json:"store_secret_key"`
+		SecretKeyCommand []string          `json:"secret_key_command"`
+	}
+	if !decodeRawState(req, resp, &prior) {
+		return
+	}
+
+	state := projectResourceModel{
+		ID:             types.StringPointerValue(prior.ID),
+		Name:           types.StringPointerValue(prior.Name),
+		IgnoreCase:     types.BoolNull(),
+		OrganizationID: types.StringPointerValue(prior.OrganizationID),
+		Metadata:       types.MapNull(types.StringType),
+		DuplicateCheck: types.StringNull(),
+		PublicKey:      types.StringPointerValue(prior.PublicKey),
+		SecretKey:      types.StringPointerValue(prior.SecretKey),
+		SecretKeyHash:  types.StringNull(),
+		StoreSecretKey: types.BoolValue(true),
+		SecretKeyCmd:   types.ListNull(types.StringType),
+		Timeouts:       nullTimeouts(),
+	}
+	// The fingerprint was added later; version 1 state always has it when the
+	// key is known.
+	if k := state.SecretKey.ValueString(); k != "" {
+		state.SecretKeyHash = types.StringValue(secretKeyFingerprint(k))
+	}
+	if prior.StoreSecretKey != nil {
+		state.StoreSecretKey = types.BoolValue(*prior.StoreSecretKey)
+	}
+	if prior.Metadata != nil {
+		m, diags := types.MapValueFrom(ctx, types.StringType, prior.Metadata)
+		resp.Diagnostics.Append(diags...)
+		state.Metadata = m
+	}
+	if prior.SecretKeyCommand != nil {
+		l, diags := types.ListValueFrom(ctx, types.StringType, prior.SecretKeyCommand)
+		resp.Diagnostics.Append(diags...)
+		state.SecretKeyCmd = l
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
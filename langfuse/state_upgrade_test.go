@@ -0,0 +1,109 @@
+package langfuse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeState runs the version 0 upgrader of r on the JSON state raw.
+func upgradeState(t *testing.T, r resource.ResourceWithUpgradeState, raw string) resource.UpgradeStateResponse {
+	t.Helper()
+	resp := resource.UpgradeStateResponse{State: newState(t, resourceSchema(t, r), nil)}
+	upgrader := r.UpgradeState(context.Background())[0]
+	upgrader.StateUpgrader(context.Background(), resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(raw)}}, &resp)
+	return resp
+}
+
+func TestUpgradeOrganizationStateV0(t *testing.T) {
+	resp := upgradeState(t, &organizationResource{}, `{"id":"org-1","name":"team-a"}`)
+	requireNoErrors(t, resp.Diagnostics)
+	var got organizationResourceModel
+	requireNoErrors(t, resp.State.Get(context.Background(), &got))
+	want := organizationResourceModel{ID: types.StringValue("org-1"), Name: types.StringValue("team-a"), IgnoreCase: types.BoolNull(), Timeouts: nullTimeouts()}
+	if !got.ID.Equal(want.ID) || !got.Name.Equal(want.Name) || !got.IgnoreCase.Equal(want.IgnoreCase) || !got.Timeouts.Equal(want.Timeouts) {
+		t.Errorf("upgraded state = %+v, want %+v", got, want)
+	}
+
+	resp = upgradeState(t, &organizationResource{}, `{"id":`)
+	requireError(t, resp.Diagnostics, "Unable to upgrade state")
+}
+
+func TestUpgradeProjectStateV0(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name             string
+		raw              string
+		wantSecretKey    types.String
+		wantFingerprint  types.String
+		wantStoreSecret  bool
+		wantMetadata     map[string]string
+		wantSecretKeyCmd []string
+	}{
+		{
+			// The first version 0 shape, before store_secret_key existed. The
+			// fingerprint is missing and derived from the stored key.
+			name:            "minimal",
+			raw:             `{"id":"proj-1","name":"search","organization_id":"org-1","public_key":"pk-lf-1","secret_key":"sk-lf-1"}`,
+			wantSecretKey:   types.StringValue("sk-lf-1"),
+			wantFingerprint: types.StringValue(secretKeyFingerprint("sk-lf-1")),
+			wantStoreSecret: true,
+		},
+		{
+			name:             "later attributes",
+			raw:              `{"id":"proj-1","name":"search","organization_id":"org-1","metadata":{"team":"search"},"public_key":"pk-lf-1","secret_key":null,"store_secret_key":false,"secret_key_command":["vault","kv","put"]}`,
+			wantSecretKey:    types.StringNull(),
+			wantFingerprint:  types.StringNull(),
+			wantMetadata:     map[string]string{"team": "search"},
+			wantSecretKeyCmd: []string{"vault", "kv", "put"},
+		},
+		{
+			name:            "explicit nulls",
+			raw:             `{"id":"proj-1","name":"search","organization_id":"org-1","metadata":null,"public_key":"pk-lf-1","secret_key":"sk-lf-1","store_secret_key":true,"secret_key_command":null}`,
+			wantSecretKey:   types.StringValue("sk-lf-1"),
+			wantFingerprint: types.StringValue(secretKeyFingerprint("sk-lf-1")),
+			wantStoreSecret: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := upgradeState(t, &projectResource{}, tt.raw)
+			requireNoErrors(t, resp.Diagnostics)
+			var got projectResourceModel
+			requireNoErrors(t, resp.State.Get(ctx, &got))
+
+			if got.ID.ValueString() != "proj-1" || got.Name.ValueString() != "search" || got.OrganizationID.ValueString() != "org-1" || got.PublicKey.ValueString() != "pk-lf-1" {
+				t.Errorf("identifying attributes = %s %s %s %s", got.ID, got.Name, got.OrganizationID, got.PublicKey)
+			}
+			if !got.SecretKey.Equal(tt.wantSecretKey) || !got.SecretKeyHash.Equal(tt.wantFingerprint) {
+				t.Errorf("secret_key, secret_key_fingerprint = %s, %s; want %s, %s", got.SecretKey, got.SecretKeyHash, tt.wantSecretKey, tt.wantFingerprint)
+			}
+			if got.StoreSecretKey.ValueBool() != tt.wantStoreSecret {
+				t.Errorf("store_secret_key = %s, want %t", got.StoreSecretKey, tt.wantStoreSecret)
+			}
+			wantMetadata := types.MapNull(types.StringType)
+			if tt.wantMetadata != nil {
+				wantMetadata = metadataValue(t, tt.wantMetadata)
+			}
+			if !got.Metadata.Equal(wantMetadata) {
+				t.Errorf("metadata = %s, want %s", got.Metadata, wantMetadata)
+			}
+			wantCmd := types.ListNull(types.StringType)
+			if tt.wantSecretKeyCmd != nil {
+				wantCmd, _ = types.ListValueFrom(ctx, types.StringType, tt.wantSecretKeyCmd)
+			}
+			if !got.SecretKeyCmd.Equal(wantCmd) {
+				t.Errorf("secret_key_command = %s, want %s", got.SecretKeyCmd, wantCmd)
+			}
+			if !got.IgnoreCase.IsNull() || !got.DuplicateCheck.IsNull() || !got.Timeouts.Equal(nullTimeouts()) {
+				t.Errorf("attributes added in version 1 = %s, %s, %v; want null", got.IgnoreCase, got.DuplicateCheck, got.Timeouts)
+			}
+		})
+	}
+
+	resp := upgradeState(t, &projectResource{}, `{"metadata":"not a map"}`)
+	requireError(t, resp.Diagnostics, "Unable to upgrade state")
+}
@@ -0,0 +1,99 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccNamePrefix starts the names of all objects created by acceptance
+// tests, so sweepers can tell them apart from real ones.
+const testAccNamePrefix = "acc-test-"
+
+// TestMain enables sweepers, which run instead of the tests when the -sweep
+// flag is given, e.g. go test ./langfuse -v -sweep=all.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("langfuse_project", &resource.Sweeper{
+		Name: "langfuse_project",
+		F:    sweepProjects,
+	})
+	resource.AddTestSweepers("langfuse_organization", &resource.Sweeper{
+		Name:         "langfuse_organization",
+		F:            sweepOrganizations,
+		Dependencies: []string{"langfuse_project"},
+	})
+}
+
+// sweeperClient returns a client for the instance named by LANGFUSE_BASE_URL
+// and LANGFUSE_ADMIN_API_KEY.
+func sweeperClient() (*client.Client, error) {
+	baseURL, adminKey := os.Getenv("LANGFUSE_BASE_URL"), os.Getenv("LANGFUSE_ADMIN_API_KEY")
+	if baseURL == "" || adminKey == "" {
+		return nil, errors.New("LANGFUSE_BASE_URL and LANGFUSE_ADMIN_API_KEY must be set to run sweepers")
+	}
+	return client.NewClient(baseURL, adminKey), nil
+}
+
+// sweepProjects deletes test projects from every organization. The region
+// argument is not used; Langfuse has none.
+func sweepProjects(string) error {
+	c, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	orgs, err := c.ListOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, org := range orgs {
+		projects, err := c.ListProjects(ctx, org.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, proj := range projects {
+			if !strings.HasPrefix(proj.Name, testAccNamePrefix) {
+				continue
+			}
+			if err := c.DeleteProject(ctx, org.ID, proj.ID); err != nil && !errors.Is(err, client.ErrNotFound) {
+				errs = append(errs, fmt.Errorf("deleting project %s (%s): %w", proj.Name, proj.ID, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// sweepOrganizations deletes test organizations.
+func sweepOrganizations(string) error {
+	c, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	orgs, err := c.ListOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, org := range orgs {
+		if !strings.HasPrefix(org.Name, testAccNamePrefix) {
+			continue
+		}
+		if err := c.DeleteOrganization(ctx, org.ID); err != nil && !errors.Is(err, client.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("deleting organization %s (%s): %w", org.Name, org.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,12 @@
+package langfuse
+
+import "time"
+
+// Default operation timeouts of the resources. Each can be overridden in the
+// resource's timeouts block; they bound the whole operation including retries.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
+)
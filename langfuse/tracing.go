@@ -0,0 +1,69 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// newTracerProvider creates a tracer provider exporting spans over OTLP/HTTP.
+// Without an explicit endpoint the exporter honors the standard
+// OTEL_EXPORTER_OTLP_* environment variables.
+func newTracerProvider(ctx context.Context, version, endpoint string, headers map[string]string) (*sdktrace.TracerProvider, error) {
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	if len(headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("terraform-provider-langfuse"),
+		semconv.ServiceVersion(version),
+	)
+
+	// Spans are exported in batches in the background, so a slow or
+	// unreachable collector does not hold up API calls. ShutdownTracing
+	// flushes what is left when the plugin exits.
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	tracerProviders.mu.Lock()
+	tracerProviders.list = append(tracerProviders.list, tp)
+	tracerProviders.mu.Unlock()
+	return tp, nil
+}
+
+// tracerProviders holds the tracer providers created by Configure, which may
+// run once per provider configuration in a process.
+var tracerProviders struct {
+	mu   sync.Mutex
+	list []*sdktrace.TracerProvider
+}
+
+// ShutdownTracing exports the spans still queued by the tracer providers and
+// stops them. It is meant to be called once the plugin server has stopped.
+func ShutdownTracing(ctx context.Context) error {
+	tracerProviders.mu.Lock()
+	list := tracerProviders.list
+	tracerProviders.list = nil
+	tracerProviders.mu.Unlock()
+
+	var errs []error
+	for _, tp := range list {
+		errs = append(errs, tp.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package langfuse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTracerProviderExportsInBackground(t *testing.T) {
+	var exports atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A slow collector.
+		time.Sleep(200 * time.Millisecond)
+		exports.Add(1)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	tp, err := newTracerProvider(ctx, "test", srv.URL+"/v1/traces", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, span := tp.Tracer("test").Start(ctx, "GET /api/public/health")
+	span.End()
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("ending a span took %s, want it not to wait for the collector", elapsed)
+	}
+
+	if err := ShutdownTracing(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if exports.Load() == 0 {
+		t.Error("ShutdownTracing did not export the queued span")
+	}
+	if err := ShutdownTracing(ctx); err != nil {
+		t.Errorf("second ShutdownTracing = %v, want nothing left to stop", err)
+	}
+}
@@ -0,0 +1,155 @@
+package langfuse
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// oneOfValidator rejects string values that are not in a fixed set, so typos
+// in enum-like attributes are reported at plan time instead of by the API.
+type oneOfValidator struct {
+	values []string
+}
+
+var _ validator.String = oneOfValidator{}
+
+// stringOneOf returns a validator accepting exactly the given values.
+// Matching is case-sensitive, as it is in the Langfuse API.
+func stringOneOf(values ...string) validator.String {
+	return oneOfValidator{values: values}
+}
+
+// Description implements validator.Describer.
+func (v oneOfValidator) Description(ctx context.Context) string {
+	return "value must be one of: " + strings.Join(v.quoted(), ", ")
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v oneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+
+	detail := fmt.Sprintf("Attribute %s must be one of %s, got %q.", req.Path, strings.Join(v.quoted(), ", "), value)
+	for _, allowed := range v.values {
+		if strings.EqualFold(value, allowed) {
+			detail += fmt.Sprintf(" Did you mean %q?", allowed)
+			break
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid attribute value", detail)
+}
+
+// quoted returns the allowed values in double quotes.
+func (v oneOfValidator) quoted() []string {
+	out := make([]string, len(v.values))
+	for i, s := range v.values {
+		out[i] = fmt.Sprintf("%q", s)
+	}
+	return out
+}
+
+// Limits Langfuse applies to organization and project names.
+const (
+	minNameLength = 3
+	maxNameLength = 60
+)
+
+// htmlTagPattern matches markup, which Langfuse rejects in names.
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// nameValidator checks organization and project names against the rules of
+// the Langfuse API, so invalid names fail at plan time rather than with a 400
+// during apply.
+type nameValidator struct{}
+
+var _ validator.String = nameValidator{}
+
+// validName returns the validator for organization and project names.
+func validName() validator.String {
+	return nameValidator{}
+}
+
+// Description implements validator.Describer.
+func (v nameValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("name must be %d to %d characters long, must not start or end with whitespace and must not contain HTML tags", minNameLength, maxNameLength)
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v nameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v nameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	name := req.ConfigValue.ValueString()
+
+	var problem string
+	switch n := utf8.RuneCountInString(name); {
+	case strings.TrimSpace(name) != name:
+		problem = "must not start or end with whitespace"
+	case n < minNameLength:
+		problem = fmt.Sprintf("must be at least %d characters long, got %d", minNameLength, n)
+	case n > maxNameLength:
+		problem = fmt.Sprintf("must be at most %d characters long, got %d", maxNameLength, n)
+	case htmlTagPattern.MatchString(name):
+		problem = "must not contain HTML tags"
+	default:
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid name", fmt.Sprintf("Name %q %s.", name, problem))
+}
+
+// durationValidator rejects strings that are not positive Go durations.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+// positiveDuration returns a validator for duration attributes like "720h".
+func positiveDuration() validator.String {
+	return durationValidator{}
+}
+
+// Description implements validator.Describer.
+func (v durationValidator) Description(ctx context.Context) string {
+	return `value must be a positive duration, e.g. "720h"`
+}
+
+// MarkdownDescription implements validator.Describer.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements validator.String.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	switch {
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", err.Error())
+	case d <= 0:
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("Duration %q must be positive.", req.ConfigValue.ValueString()))
+	}
+}
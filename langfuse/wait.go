@@ -0,0 +1,78 @@
+package langfuse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/faxe1008/terraform-provider-langfuse/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Poll intervals used while waiting for the API to reflect a change.
+const (
+	minPollInterval = 500 * time.Millisecond
+	maxPollInterval = 10 * time.Second
+)
+
+// visibilityTimeout bounds how long a newly created object may stay invisible
+// to reads, e.g. while read replicas catch up.
+const visibilityTimeout = 30 * time.Second
+
+// waitForDeletion calls get until it fails with client.ErrNotFound, backing
+// off between attempts, and gives up when ctx is done. Reads bypass the read
+// cache. what names the object in log messages and errors.
+func waitForDeletion(ctx context.Context, what string, get func(ctx context.Context) error) error {
+	ctx = client.WithoutCache(ctx)
+	return poll(ctx, "Waiting for deletion to complete", what, func(ctx context.Context) (bool, error) {
+		err := get(ctx)
+		if errors.Is(err, client.ErrNotFound) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+// waitForVisibility calls get until it no longer fails with
+// client.ErrNotFound, so that the Read following a Create does not report a
+// freshly created object as vanished. It gives up after visibilityTimeout.
+// Reads bypass the read cache, which would otherwise answer a project read
+// with a list of all projects of the organization.
+func waitForVisibility(ctx context.Context, what string, get func(ctx context.Context) error) error {
+	ctx = client.WithoutCache(ctx)
+	ctx, cancel := context.WithTimeout(ctx, visibilityTimeout)
+	defer cancel()
+	return poll(ctx, "Waiting for created object to become readable", what, func(ctx context.Context) (bool, error) {
+		err := get(ctx)
+		if errors.Is(err, client.ErrNotFound) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// poll calls check until it reports done or fails, backing off between
+// attempts, and gives up when ctx is done.
+func poll(ctx context.Context, msg, what string, check func(ctx context.Context) (bool, error)) error {
+	interval := minPollInterval
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		tflog.Debug(ctx, msg, map[string]interface{}{
+			"object":  what,
+			"wait_ms": interval.Milliseconds(),
+		})
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up waiting for %s: %w", what, ctx.Err())
+		}
+		interval = min(interval*2, maxPollInterval)
+	}
+}
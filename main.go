@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -24,7 +25,14 @@ func main() {
 		return langfuse.NewProvider("0.1.0")
 	}
 
-	if err := providerserver.Serve(context.Background(), providerConstructor, opts); err != nil {
+	err := providerserver.Serve(context.Background(), providerConstructor, opts)
+
+	// Flush the spans of the last operations before the process exits.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	langfuse.ShutdownTracing(ctx)
+	cancel()
+
+	if err != nil {
 		os.Exit(1)
 	}
 }